@@ -58,6 +58,43 @@ type Config struct {
 	Debug            bool     `yaml:"debug"`
 	PlatformTenantID string   `yaml:"platformTenantID"`
 	EnableTenant     bool     `yaml:"enableTenant"`
+	FullTextSearch   bool     `yaml:"fullTextSearch"`
 	BasicForm        []string `yaml:"basicForm"`
 	BasicPage        []string `yaml:"basicPage"`
+	Webhook          Webhook  `yaml:"webhook"`
+	// Encryption 元数据中加密字段使用的密钥
+	Encryption Encryption `yaml:"encryption"`
+	Snowflake  Snowflake  `yaml:"snowflake"`
+}
+
+// Snowflake 雪花算法的配置
+type Snowflake struct {
+	// NodeID 节点ID,取值0-1023,多个实例需要配置不同的值
+	NodeID int64 `yaml:"nodeID"`
+}
+
+// Encryption 敏感字段的加密配置
+type Encryption struct {
+	// Key base64编码的AES密钥,长度为16、24或者32字节
+	Key string `yaml:"key"`
+	// BlindIndexKey 计算盲索引的HMAC密钥,为空时从Key派生
+	BlindIndexKey string `yaml:"blindIndexKey"`
+}
+
+// Webhook 页面webhook的推送配置
+type Webhook struct {
+	// Disable 不在当前实例中推送,事件仍然会写入outbox
+	Disable bool `yaml:"disable"`
+	// Interval 检查待推送事件的间隔秒数,默认5秒
+	Interval int `yaml:"interval"`
+	// Timeout 每次推送的超时秒数,默认10秒
+	Timeout int `yaml:"timeout"`
+	// MaxAttempts 最多推送次数,默认10次
+	MaxAttempts int `yaml:"maxAttempts"`
+	// Backoff 第一次推送失败后等待的秒数,之后每次翻倍,默认10秒
+	Backoff int `yaml:"backoff"`
+	// MaxBackoff 推送失败后最多等待的秒数,默认3600秒
+	MaxBackoff int `yaml:"maxBackoff"`
+	// RetentionDays 推送完成的记录和事件保留的天数,默认30天,小于0表示不清理
+	RetentionDays int `yaml:"retentionDays"`
 }
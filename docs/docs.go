@@ -613,7 +613,7 @@ const docTemplate = `{
         },
         "/api/curd/common/{pageName}/add": {
             "post": {
-                "description": "新增",
+                "description": "新增,子表字段(数组)中的记录会在同一个事务中新增\n页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚",
                 "consumes": [
                     "application/json"
                 ],
@@ -653,15 +653,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.SaveResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/common/{pageName}/all": {
+        "/api/curd/common/{pageName}/aggregate": {
             "get": {
-                "description": "查询所有",
+                "description": "按照分组字段统计记录数量、合计、平均值、最小值和最大值,查询条件和分页查询一致",
                 "consumes": [
                     "application/json"
                 ],
@@ -671,7 +671,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "查询所有",
+                "summary": "分组统计",
                 "parameters": [
                     {
                         "type": "string",
@@ -686,21 +686,51 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询条件,JSON格式,和分页查询一致",
+                        "name": "data",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分组字段,多个使用逗号隔开,日期字段可以加:day、:week、:month按天、周、月分组,例如status,createdAt:month",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "统计函数,多个使用逗号隔开,格式为函数:字段,支持count、sum、avg、min、max,例如count,sum:amount,默认为count",
+                        "name": "metrics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "平台租户是否查询所有租户的数据",
+                        "name": "allTenants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字,在所有开启了Like的字段中模糊查询",
+                        "name": "keyword",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.QueryResponse"
+                            "$ref": "#/definitions/model.AggregateResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/common/{pageName}/delete": {
-            "delete": {
-                "description": "删除",
+        "/api/curd/common/{pageName}/all": {
+            "get": {
+                "description": "查询所有",
                 "consumes": [
                     "application/json"
                 ],
@@ -710,7 +740,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "删除",
+                "summary": "查询所有",
                 "parameters": [
                     {
                         "type": "string",
@@ -725,30 +755,21 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Delete Page",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.QueryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/common/{pageName}/detail": {
-            "get": {
-                "description": "查询明细",
+        "/api/curd/common/{pageName}/batch/delete": {
+            "delete": {
+                "description": "批量删除",
                 "consumes": [
                     "application/json"
                 ],
@@ -758,7 +779,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "查询明细",
+                "summary": "批量删除",
                 "parameters": [
                     {
                         "type": "string",
@@ -767,32 +788,36 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Batch Delete",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.CommonDetailResponse"
+                            "$ref": "#/definitions/model.BatchResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/curd/common/{pageName}/batch/enable": {
             "post": {
-                "description": "复制",
+                "description": "批量禁用/启用",
                 "consumes": [
                     "application/json"
                 ],
@@ -802,7 +827,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "复制",
+                "summary": "批量禁用/启用",
                 "parameters": [
                     {
                         "type": "string",
@@ -811,36 +836,36 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "Copy Page",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Batch Enable/Disable",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.BatchResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/common/{pageName}/detail/name": {
-            "get": {
-                "description": "根据名称查询明细",
+        "/api/curd/common/{pageName}/batch/update": {
+            "put": {
+                "description": "批量更新",
                 "consumes": [
                     "application/json"
                 ],
@@ -850,7 +875,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "根据名称查询明细",
+                "summary": "批量更新",
                 "parameters": [
                     {
                         "type": "string",
@@ -859,34 +884,36 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "名称",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Batch Update",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.CommonDetailResponse"
+                            "$ref": "#/definitions/model.BatchResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/common/{pageName}/enable": {
-            "post": {
-                "description": "禁用/启用",
+        "/api/curd/common/{pageName}/delete": {
+            "delete": {
+                "description": "删除\n页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚",
                 "consumes": [
                     "application/json"
                 ],
@@ -896,7 +923,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "禁用/启用",
+                "summary": "删除",
                 "parameters": [
                     {
                         "type": "string",
@@ -913,12 +940,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Enable/Disable Page",
+                        "description": "Delete Page",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.EnableRequest"
+                            "$ref": "#/definitions/curd.DelRequest"
                         }
                     }
                 ],
@@ -932,9 +959,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/common/{pageName}/query": {
+        "/api/curd/common/{pageName}/detail": {
             "get": {
-                "description": "分页查询",
+                "description": "查询明细",
                 "consumes": [
                     "application/json"
                 ],
@@ -944,7 +971,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "分页查询",
+                "summary": "查询明细",
                 "parameters": [
                     {
                         "type": "string",
@@ -955,49 +982,42 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "jwt token",
-                        "name": "authorization",
-                        "in": "header",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "从1开始",
-                        "name": "pageIndex",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "默认每页10条",
-                        "name": "pageSize",
+                        "type": "string",
+                        "description": "需要展开的引用字段,多个使用逗号隔开,使用.展开下一层",
+                        "name": "expand",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "排序字段",
-                        "name": "orderField",
+                        "description": "展开方式,object返回完整记录(默认),label只返回LabelField",
+                        "name": "expandMode",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "是否倒序排序",
-                        "name": "desc",
-                        "in": "query"
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.QueryResponse"
+                            "$ref": "#/definitions/model.CommonDetailResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/curd/common/{pageName}/tree": {
-            "get": {
-                "description": "树形数据",
+            },
+            "post": {
+                "description": "复制",
                 "consumes": [
                     "application/json"
                 ],
@@ -1007,7 +1027,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "树形数据",
+                "summary": "复制",
                 "parameters": [
                     {
                         "type": "string",
@@ -1016,6 +1036,15 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Copy Page",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1028,15 +1057,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.QueryResponse"
+                            "$ref": "#/definitions/curd.CommonResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/common/{pageName}/update": {
-            "put": {
-                "description": "更新",
+        "/api/curd/common/{pageName}/detail/name": {
+            "get": {
+                "description": "根据名称查询明细",
                 "consumes": [
                     "application/json"
                 ],
@@ -1046,7 +1075,7 @@ const docTemplate = `{
                 "tags": [
                     "通用增删改查接口"
                 ],
-                "summary": "更新",
+                "summary": "根据名称查询明细",
                 "parameters": [
                     {
                         "type": "string",
@@ -1055,36 +1084,46 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "需要展开的引用字段,多个使用逗号隔开,使用.展开下一层",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "展开方式,object返回完整记录(默认),label只返回LabelField",
+                        "name": "expandMode",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Update Object",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.AddRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.CommonDetailResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/add": {
+        "/api/curd/common/{pageName}/enable": {
             "post": {
-                "description": "新增",
+                "description": "禁用/启用",
                 "consumes": [
                     "application/json"
                 ],
@@ -1092,10 +1131,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "新增",
+                "summary": "禁用/启用",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1104,12 +1150,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Add FileTemplate",
-                        "name": "account",
+                        "description": "Enable/Disable Page",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.FileTemplateInfo"
+                            "$ref": "#/definitions/curd.EnableRequest"
                         }
                     }
                 ],
@@ -1123,41 +1169,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/filetemplate/all": {
+        "/api/curd/common/{pageName}/export": {
             "get": {
-                "description": "查询所有",
+                "description": "按查询条件导出数据,支持csv和xlsx两种格式\n文本以=、+、-、@开头时前面加上单引号,避免在Excel中被当成公式\ncsv输出过程中出错时最后一行是\"#导出失败:\"和错误信息",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "查询所有",
+                "summary": "导出",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/curd.GetAllFileTemplateResponse"
-                        }
-                    }
-                }
-            }
+                    },
+                    {
+                        "type": "string",
+                        "description": "导出格式:csv或者xlsx,默认csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询条件,JSON格式,和分页查询的查询条件一致",
+                        "name": "data",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字,在所有开启了Like的字段中模糊查询",
+                        "name": "keyword",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
         },
-        "/api/curd/filetemplate/copy": {
-            "post": {
-                "description": "复制",
+        "/api/curd/common/{pageName}/history": {
+            "get": {
+                "description": "分页查询记录的变更历史,页面需要开启变更历史",
                 "consumes": [
                     "application/json"
                 ],
@@ -1165,18 +1248,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "复制",
+                "summary": "变更历史",
                 "parameters": [
                     {
-                        "description": "Copy FileTemplate",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
+                            "$ref": "#/definitions/model.HistoryResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/curd/common/{pageName}/import": {
+            "post": {
+                "description": "导入csv或者xlsx文件,表头可以是页面字段的标题或者元数据字段的显示名称,返回每一行的导入结果",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通用增删改查接口"
+                ],
+                "summary": "导入",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -1184,21 +1323,34 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "要导入的文件,工具栏开启了导入多个文件时可以上传多个,最多上传导入最大数量个",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "为true时所有数据都导入成功才提交",
+                        "name": "transaction",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.ImportResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/delete": {
-            "delete": {
-                "description": "删除",
+        "/api/curd/common/{pageName}/patch": {
+            "patch": {
+                "description": "只更新传入的字段,没有传入的字段保持不变,和原记录合并后再校验\n页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚",
                 "consumes": [
                     "application/json"
                 ],
@@ -1206,10 +1358,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "删除",
+                "summary": "部分更新",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1218,12 +1377,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete FileTemplate",
+                        "description": "Patch Object",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
+                            "$ref": "#/definitions/http.AddRequest"
                         }
                     }
                 ],
@@ -1231,15 +1390,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.SaveResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/detail": {
-            "get": {
-                "description": "查询明细",
+        "/api/curd/common/{pageName}/purge": {
+            "delete": {
+                "description": "彻底删除回收站中的记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -1247,15 +1406,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "查询明细",
+                "summary": "彻底删除",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
                         "required": true
                     },
                     {
@@ -1264,32 +1423,48 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Purge",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetFileTemplateDetailResponse"
+                            "$ref": "#/definitions/model.BatchResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/export": {
+        "/api/curd/common/{pageName}/query": {
             "get": {
-                "description": "导出",
+                "description": "分页查询",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "导出",
+                "summary": "分页查询",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1323,17 +1498,50 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "文件名称",
-                        "name": "name",
+                        "description": "查询条件,JSON格式,字段名可以加操作符后缀:__eq,__ne,__gt,__gte,__lt,__lte,__like,__in,__nin,__isnull,__between,例如{\\",
+                        "name": "data",
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "description": "IDs",
-                        "name": "ids",
+                        "type": "boolean",
+                        "description": "平台租户是否查询所有租户的数据",
+                        "name": "allTenants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "需要展开的引用字段,多个使用逗号隔开,使用.展开下一层,最多3层,例如role,dept.parent",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "展开方式,object返回完整记录(默认),label只返回LabelField",
+                        "name": "expandMode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "使用游标分页,按照排序字段和id定位下一页,适合数据量大的表",
+                        "name": "useCursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上一页返回的nextCursor,传了cursor时自动使用游标分页",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "不查询总记录数",
+                        "name": "skipCount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键字,在所有开启了Like的字段中模糊查询",
+                        "name": "keyword",
                         "in": "query"
                     }
                 ],
@@ -1341,65 +1549,81 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.QueryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/import": {
+        "/api/curd/common/{pageName}/restore": {
             "post": {
-                "description": "导入",
+                "description": "恢复回收站中的记录",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "导入",
+                "summary": "恢复",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer+空格+Token",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "要上传的文件",
-                        "name": "files",
-                        "in": "formData",
-                        "required": true
+                        "description": "Restore",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.BatchResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/query": {
+        "/api/curd/common/{pageName}/trash": {
             "get": {
-                "description": "分页查询",
+                "description": "分页查询已经删除的记录,元数据需要有DeletedAt字段",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "分页查询",
+                "summary": "回收站",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1433,8 +1657,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "文件名称",
-                        "name": "name",
+                        "description": "查询条件,JSON格式,和分页查询的查询条件一致",
+                        "name": "data",
                         "in": "query"
                     }
                 ],
@@ -1442,56 +1666,54 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.QueryFileTemplateResponse"
+                            "$ref": "#/definitions/model.QueryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/filetemplate/update": {
-            "put": {
-                "description": "更新",
-                "consumes": [
+        "/api/curd/common/{pageName}/tree": {
+            "get": {
+                "description": "树形数据",
+                "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "文件模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "更新",
+                "summary": "树形数据",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Update FileTemplate",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.FileTemplateInfo"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.QueryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/add": {
-            "post": {
-                "description": "新增",
+        "/api/curd/common/{pageName}/tree/children": {
+            "get": {
+                "description": "查询直接子节点,用于树的懒加载,isLeaf表示是否没有子节点",
                 "consumes": [
                     "application/json"
                 ],
@@ -1499,10 +1721,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "新增",
+                "summary": "树形数据子节点",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1511,28 +1740,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Add FunctionalTemplate",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.FunctionalTemplateInfo"
-                        }
+                        "type": "string",
+                        "description": "父节点ID,为空时查询根节点",
+                        "name": "parentID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.QueryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/all": {
-            "get": {
-                "description": "查询所有",
+        "/api/curd/common/{pageName}/tree/move": {
+            "post": {
+                "description": "修改节点的父节点,重新计算节点和所有子节点的level,不能移动到自己或者自己的子节点下面",
                 "consumes": [
                     "application/json"
                 ],
@@ -1540,31 +1766,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "查询所有",
+                "summary": "移动树形节点",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Move Tree Node",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TreeMoveRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetAllFunctionalTemplateResponse"
+                            "$ref": "#/definitions/model.CommonResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/copy": {
-            "post": {
-                "description": "复制",
+        "/api/curd/common/{pageName}/tree/path": {
+            "get": {
+                "description": "查询从根节点到指定节点的所有节点,用于面包屑导航",
                 "consumes": [
                     "application/json"
                 ],
@@ -1572,18 +1814,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "复制",
+                "summary": "树形数据路径",
                 "parameters": [
                     {
-                        "description": "Copy FunctionalTemplate",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -1591,21 +1831,28 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "节点ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.QueryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/delete": {
-            "delete": {
-                "description": "删除",
+        "/api/curd/common/{pageName}/update": {
+            "put": {
+                "description": "更新,传了version或者updatedAt时会检查记录是否已经被其他人修改,已修改返回40003\n传了子表字段(数组)时,有id的子表记录更新,没有id的新增,不在数组中的删除\n页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚",
                 "consumes": [
                     "application/json"
                 ],
@@ -1613,10 +1860,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "删除",
+                "summary": "更新",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -1625,12 +1879,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete FunctionalTemplate",
+                        "description": "Update Object",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
+                            "$ref": "#/definitions/http.AddRequest"
                         }
                     }
                 ],
@@ -1638,15 +1892,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/model.SaveResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/detail": {
+        "/api/curd/common/{pageName}/webhook/deliveries": {
             "get": {
-                "description": "查询明细",
+                "description": "分页查询页面的webhook推送记录,失败的推送会按照指数退避重试,超过最多次数后状态为failed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1654,15 +1908,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "通用增删改查接口"
                 ],
-                "summary": "查询明细",
+                "summary": "webhook推送记录",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "页面配置名称",
+                        "name": "pageName",
+                        "in": "path",
                         "required": true
                     },
                     {
@@ -1671,21 +1925,51 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "recordID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件ID",
+                        "name": "eventID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending,success,failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetFunctionalTemplateDetailResponse"
+                            "$ref": "#/definitions/model.WebhookDeliveryResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/enable": {
+        "/api/curd/filetemplate/add": {
             "post": {
-                "description": "禁用/启用",
+                "description": "新增",
                 "consumes": [
                     "application/json"
                 ],
@@ -1693,9 +1977,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "文件模版管理"
                 ],
-                "summary": "禁用/启用",
+                "summary": "新增",
                 "parameters": [
                     {
                         "type": "string",
@@ -1705,12 +1989,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Enable/Disable FunctionalTemplate",
+                        "description": "Add FileTemplate",
                         "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.EnableRequest"
+                            "$ref": "#/definitions/curd.FileTemplateInfo"
                         }
                     }
                 ],
@@ -1724,19 +2008,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/functionaltemplate/export": {
+        "/api/curd/filetemplate/all": {
             "get": {
-                "description": "导出",
+                "description": "查询所有",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "文件模版管理"
                 ],
-                "summary": "导出",
+                "summary": "查询所有",
                 "parameters": [
                     {
                         "type": "string",
@@ -1744,96 +2028,47 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "从1开始",
-                        "name": "pageIndex",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "默认每页10条",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "排序字段",
-                        "name": "orderField",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否倒序排序",
-                        "name": "desc",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "语音",
-                        "name": "language",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "分组",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "description": "IDs",
-                        "name": "ids",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/curd.GetAllFileTemplateResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/import": {
+        "/api/curd/filetemplate/copy": {
             "post": {
-                "description": "导入",
+                "description": "复制",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "文件模版管理"
                 ],
-                "summary": "导入",
+                "summary": "复制",
                 "parameters": [
+                    {
+                        "description": "Copy FileTemplate",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Bearer+空格+Token",
+                        "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "要上传的文件",
-                        "name": "files",
-                        "in": "formData",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1846,19 +2081,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/functionaltemplate/query": {
-            "get": {
-                "description": "分页查询",
+        "/api/curd/filetemplate/delete": {
+            "delete": {
+                "description": "删除",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "文件模版管理"
                 ],
-                "summary": "分页查询",
+                "summary": "删除",
                 "parameters": [
                     {
                         "type": "string",
@@ -1868,61 +2103,28 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "从1开始",
-                        "name": "pageIndex",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "默认每页10条",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "排序字段",
-                        "name": "orderField",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否倒序排序",
-                        "name": "desc",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "语音",
-                        "name": "language",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "分组",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "名称",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Delete FileTemplate",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.QueryFunctionalTemplateResponse"
+                            "$ref": "#/definitions/curd.CommonResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/functionaltemplate/update": {
-            "put": {
-                "description": "更新",
+        "/api/curd/filetemplate/detail": {
+            "get": {
+                "description": "查询明细",
                 "consumes": [
                     "application/json"
                 ],
@@ -1930,38 +2132,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "功能模版管理"
+                    "文件模版管理"
                 ],
-                "summary": "更新",
+                "summary": "查询明细",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Update FunctionalTemplate",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.FunctionalTemplateInfo"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/curd.GetFileTemplateDetailResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/metadata/export": {
+        "/api/curd/filetemplate/export": {
             "get": {
                 "description": "导出",
                 "consumes": [
@@ -1971,7 +2171,7 @@ const docTemplate = `{
                     "application/octet-stream"
                 ],
                 "tags": [
-                    "Metadata管理"
+                    "文件模版管理"
                 ],
                 "summary": "导出",
                 "parameters": [
@@ -2006,6 +2206,12 @@ const docTemplate = `{
                         "name": "desc",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "文件名称",
+                        "name": "name",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
@@ -2026,35 +2232,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/page/add": {
+        "/api/curd/filetemplate/import": {
             "post": {
-                "description": "新增页面",
+                "description": "导入",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "文件模版管理"
                 ],
-                "summary": "新增页面",
+                "summary": "导入",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "jwt token",
+                        "description": "Bearer+空格+Token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Add Page",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.PageInfo"
-                        }
+                        "type": "file",
+                        "description": "要上传的文件",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2067,19 +2271,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/page/all": {
+        "/api/curd/filetemplate/query": {
             "get": {
-                "description": "查询所有页面配置",
+                "description": "分页查询",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "页面配置"
+                    "文件模版管理"
                 ],
-                "summary": "查询所有页面配置",
+                "summary": "分页查询",
                 "parameters": [
                     {
                         "type": "string",
@@ -2087,21 +2291,51 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名称",
+                        "name": "name",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetAllPageResponse"
+                            "$ref": "#/definitions/curd.QueryFileTemplateResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/page/delete": {
-            "delete": {
-                "description": "删除页面配置",
+        "/api/curd/filetemplate/update": {
+            "put": {
+                "description": "更新",
                 "consumes": [
                     "application/json"
                 ],
@@ -2109,9 +2343,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "文件模版管理"
                 ],
-                "summary": "删除页面配置",
+                "summary": "更新",
                 "parameters": [
                     {
                         "type": "string",
@@ -2121,12 +2355,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete Page",
-                        "name": "data",
+                        "description": "Update FileTemplate",
+                        "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
+                            "$ref": "#/definitions/curd.FileTemplateInfo"
                         }
                     }
                 ],
@@ -2140,9 +2374,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/page/detail": {
-            "get": {
-                "description": "查询明细",
+        "/api/curd/functionaltemplate/add": {
+            "post": {
+                "description": "新增",
                 "consumes": [
                     "application/json"
                 ],
@@ -2150,17 +2384,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
-                "summary": "查询明细",
+                "summary": "新增",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
                         "required": true
                     },
+                    {
+                        "description": "Add FunctionalTemplate",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.FunctionalTemplateInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/curd/functionaltemplate/all": {
+            "get": {
+                "description": "查询所有",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "功能模版管理"
+                ],
+                "summary": "查询所有",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -2173,13 +2441,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetPageDetailResponse"
+                            "$ref": "#/definitions/curd.GetAllFunctionalTemplateResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/curd/functionaltemplate/copy": {
             "post": {
-                "description": "复制页面配置",
+                "description": "复制",
                 "consumes": [
                     "application/json"
                 ],
@@ -2187,12 +2457,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
-                "summary": "复制页面配置",
+                "summary": "复制",
                 "parameters": [
                     {
-                        "description": "Copy Page",
+                        "description": "Copy FunctionalTemplate",
                         "name": "data",
                         "in": "body",
                         "required": true,
@@ -2218,9 +2488,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/page/detail/name": {
-            "get": {
-                "description": "根据名称查询明细",
+        "/api/curd/functionaltemplate/delete": {
+            "delete": {
+                "description": "删除",
                 "consumes": [
                     "application/json"
                 ],
@@ -2228,14 +2498,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
-                "summary": "根据名称查询明细",
+                "summary": "删除",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "名称",
-                        "name": "name",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Delete FunctionalTemplate",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/curd/functionaltemplate/detail": {
+            "get": {
+                "description": "查询明细",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "功能模版管理"
+                ],
+                "summary": "查询明细",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     },
@@ -2251,15 +2562,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetPageDetailResponse"
+                            "$ref": "#/definitions/curd.GetFunctionalTemplateDetailResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/page/enable": {
+        "/api/curd/functionaltemplate/enable": {
             "post": {
-                "description": "禁用/启用页面配置",
+                "description": "禁用/启用",
                 "consumes": [
                     "application/json"
                 ],
@@ -2267,9 +2578,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
-                "summary": "禁用/启用页面配置",
+                "summary": "禁用/启用",
                 "parameters": [
                     {
                         "type": "string",
@@ -2279,8 +2590,8 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Enable/Disable Page",
-                        "name": "data",
+                        "description": "Enable/Disable FunctionalTemplate",
+                        "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -2298,7 +2609,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/page/export": {
+        "/api/curd/functionaltemplate/export": {
             "get": {
                 "description": "导出",
                 "consumes": [
@@ -2308,7 +2619,7 @@ const docTemplate = `{
                     "application/octet-stream"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
                 "summary": "导出",
                 "parameters": [
@@ -2345,20 +2656,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "名称",
-                        "name": "name",
+                        "description": "语音",
+                        "name": "language",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "是否启用",
-                        "name": "enable",
+                        "type": "string",
+                        "description": "分组",
+                        "name": "group",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "类型",
-                        "name": "type",
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
                         "in": "query"
                     },
                     {
@@ -2375,13 +2686,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.QueryPageResponse"
+                            "$ref": "#/definitions/curd.CommonResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/page/import": {
+        "/api/curd/functionaltemplate/import": {
             "post": {
                 "description": "导入",
                 "consumes": [
@@ -2391,7 +2702,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
                 "summary": "导入",
                 "parameters": [
@@ -2420,17 +2731,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/page/query": {
+        "/api/curd/functionaltemplate/query": {
             "get": {
                 "description": "分页查询",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
                 "summary": "分页查询",
                 "parameters": [
@@ -2467,29 +2778,20 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "是否启用",
-                        "name": "enable",
+                        "description": "语音",
+                        "name": "language",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "类型",
-                        "name": "type",
+                        "type": "string",
+                        "description": "分组",
+                        "name": "group",
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "description": "IDs",
-                        "name": "ids",
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
                         "in": "query"
                     }
                 ],
@@ -2497,15 +2799,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.QueryPageResponse"
+                            "$ref": "#/definitions/curd.QueryFunctionalTemplateResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/page/update": {
+        "/api/curd/functionaltemplate/update": {
             "put": {
-                "description": "更新页面配置",
+                "description": "更新",
                 "consumes": [
                     "application/json"
                 ],
@@ -2513,9 +2815,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "页面配置"
+                    "功能模版管理"
                 ],
-                "summary": "更新页面配置",
+                "summary": "更新",
                 "parameters": [
                     {
                         "type": "string",
@@ -2525,12 +2827,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update Page",
-                        "name": "data",
+                        "description": "Update FunctionalTemplate",
+                        "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.PageInfo"
+                            "$ref": "#/definitions/curd.FunctionalTemplateInfo"
                         }
                     }
                 ],
@@ -2544,19 +2846,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/systemobject/add": {
-            "post": {
-                "description": "新增",
+        "/api/curd/metadata/export": {
+            "get": {
+                "description": "导出",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "Metadata管理"
                 ],
-                "summary": "新增",
+                "summary": "导出",
                 "parameters": [
                     {
                         "type": "string",
@@ -2566,13 +2868,37 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Add SystemObject",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.SystemObjectInfo"
-                        }
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "IDs",
+                        "name": "ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2585,9 +2911,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/systemobject/all": {
-            "get": {
-                "description": "查询所有",
+        "/api/curd/page/add": {
+            "post": {
+                "description": "新增页面",
                 "consumes": [
                     "application/json"
                 ],
@@ -2595,9 +2921,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "页面配置"
                 ],
-                "summary": "查询所有",
+                "summary": "新增页面",
                 "parameters": [
                     {
                         "type": "string",
@@ -2605,21 +2931,30 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Add Page",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.PageInfo"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetAllSystemObjectResponse"
+                            "$ref": "#/definitions/curd.CommonResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/systemobject/copy": {
-            "post": {
-                "description": "复制",
+        "/api/curd/page/all": {
+            "get": {
+                "description": "查询所有页面配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -2627,19 +2962,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "页面配置"
                 ],
-                "summary": "复制",
+                "summary": "查询所有页面配置",
                 "parameters": [
-                    {
-                        "description": "Copy SystemObject",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -2652,15 +2978,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/curd.GetAllPageResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/systemobject/delete": {
+        "/api/curd/page/delete": {
             "delete": {
-                "description": "删除",
+                "description": "删除页面配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -2668,9 +2994,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "页面配置"
                 ],
-                "summary": "删除",
+                "summary": "删除页面配置",
                 "parameters": [
                     {
                         "type": "string",
@@ -2680,7 +3006,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete SystemObject",
+                        "description": "Delete Page",
                         "name": "data",
                         "in": "body",
                         "required": true,
@@ -2699,9 +3025,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/systemobject/detail": {
+        "/api/curd/page/design": {
             "get": {
-                "description": "查询明细",
+                "description": "页面设计器查询完整的页面配置,包括webhook的secret;修改页面配置时secret传回******表示不修改",
                 "consumes": [
                     "application/json"
                 ],
@@ -2709,9 +3035,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "页面配置"
                 ],
-                "summary": "查询明细",
+                "summary": "页面设计器查询明细",
                 "parameters": [
                     {
                         "type": "string",
@@ -2732,15 +3058,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetSystemObjectDetailResponse"
+                            "$ref": "#/definitions/curd.GetPageDetailResponse"
                         }
                     }
                 }
             }
         },
-        "/api/curd/systemobject/enable": {
-            "post": {
-                "description": "禁用/启用",
+        "/api/curd/page/detail": {
+            "get": {
+                "description": "查询明细,fieldAccess返回当前用户每个字段生效的权限:hidden、readonly或者readwrite,webhook已经设置的secret返回******",
                 "consumes": [
                     "application/json"
                 ],
@@ -2748,120 +3074,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "页面配置"
                 ],
-                "summary": "禁用/启用",
+                "summary": "查询明细",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "jwt token",
-                        "name": "authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     },
-                    {
-                        "description": "Enable/Disable SystemObject",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.EnableRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/curd/systemobject/query": {
-            "get": {
-                "description": "分页查询",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/octet-stream"
-                ],
-                "tags": [
-                    "系统定义的对象管理"
-                ],
-                "summary": "分页查询",
-                "parameters": [
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "从1开始",
-                        "name": "pageIndex",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "默认每页10条",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "排序字段",
-                        "name": "orderField",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否倒序排序",
-                        "name": "desc",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "编程语言",
-                        "name": "language",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "类型",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "是否启用",
-                        "name": "enable",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.QuerySystemObjectResponse"
+                            "$ref": "#/definitions/curd.GetPageDetailResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/curd/systemobject/update": {
-            "put": {
-                "description": "更新",
+            },
+            "post": {
+                "description": "复制页面配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -2869,25 +3111,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统定义的对象管理"
+                    "页面配置"
                 ],
-                "summary": "更新",
+                "summary": "复制页面配置",
                 "parameters": [
+                    {
+                        "description": "Copy Page",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Update SystemObject",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.SystemObjectInfo"
-                        }
                     }
                 ],
                 "responses": {
@@ -2900,9 +3142,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/curd/template/copy": {
-            "post": {
-                "description": "复制",
+        "/api/curd/page/detail/name": {
+            "get": {
+                "description": "根据名称查询明细,fieldAccess返回当前用户每个字段生效的权限:hidden、readonly或者readwrite,webhook已经设置的secret返回******",
                 "consumes": [
                     "application/json"
                 ],
@@ -2910,18 +3152,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "代码模板管理"
+                    "页面配置"
                 ],
-                "summary": "复制",
+                "summary": "根据名称查询明细",
                 "parameters": [
                     {
-                        "description": "Copy Template",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -2935,15 +3175,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/curd.GetPageDetailResponse"
                         }
                     }
                 }
             }
         },
-        "/api/form/add": {
+        "/api/curd/page/enable": {
             "post": {
-                "description": "新增表单",
+                "description": "禁用/启用页面配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -2951,9 +3191,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "页面配置"
                 ],
-                "summary": "新增表单",
+                "summary": "禁用/启用页面配置",
                 "parameters": [
                     {
                         "type": "string",
@@ -2963,12 +3203,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Add Form",
-                        "name": "account",
+                        "description": "Enable/Disable Page",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.FormInfo"
+                            "$ref": "#/definitions/curd.EnableRequest"
                         }
                     }
                 ],
@@ -2982,19 +3222,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/all": {
+        "/api/curd/page/export": {
             "get": {
-                "description": "查询所有表单管理",
+                "description": "导出",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "表单管理"
+                    "页面配置"
                 ],
-                "summary": "查询所有表单管理",
+                "summary": "导出",
                 "parameters": [
                     {
                         "type": "string",
@@ -3002,47 +3242,96 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "是否启用",
+                        "name": "enable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "IDs",
+                        "name": "ids",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetAllFormResponse"
+                            "$ref": "#/definitions/curd.QueryPageResponse"
                         }
                     }
                 }
             }
         },
-        "/api/form/copy": {
+        "/api/curd/page/import": {
             "post": {
-                "description": "复制表单",
+                "description": "导入",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "页面配置"
                 ],
-                "summary": "复制表单",
+                "summary": "导入",
                 "parameters": [
-                    {
-                        "description": "Copy Form",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "jwt token",
+                        "description": "Bearer+空格+Token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "要上传的文件",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3055,9 +3344,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/delete": {
-            "delete": {
-                "description": "删除表单管理",
+        "/api/curd/page/query": {
+            "get": {
+                "description": "分页查询",
                 "consumes": [
                     "application/json"
                 ],
@@ -3065,89 +3354,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "页面配置"
                 ],
-                "summary": "删除表单管理",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "jwt token",
-                        "name": "authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Delete Form",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.DelRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/form/detail": {
-            "get": {
-                "description": "查询明细",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "表单管理"
-                ],
-                "summary": "查询明细",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "jwt token",
-                        "name": "authorization",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/curd.GetFormDetailResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/form/export": {
-            "get": {
-                "description": "导出",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/octet-stream"
-                ],
-                "tags": [
-                    "表单管理"
-                ],
-                "summary": "导出",
+                "summary": "分页查询",
                 "parameters": [
                     {
                         "type": "string",
@@ -3159,13 +3368,13 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "description": "从1开始",
-                        "name": "formIndex",
+                        "name": "pageIndex",
                         "in": "query"
                     },
                     {
                         "type": "integer",
                         "description": "默认每页10条",
-                        "name": "formSize",
+                        "name": "pageSize",
                         "in": "query"
                     },
                     {
@@ -3187,15 +3396,15 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "表单名称",
-                        "name": "pageName",
+                        "type": "integer",
+                        "description": "是否启用",
+                        "name": "enable",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "分组",
-                        "name": "group",
+                        "type": "integer",
+                        "description": "类型",
+                        "name": "type",
                         "in": "query"
                     },
                     {
@@ -3212,39 +3421,41 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.CommonResponse"
+                            "$ref": "#/definitions/curd.QueryPageResponse"
                         }
                     }
                 }
             }
         },
-        "/api/form/import": {
-            "post": {
-                "description": "导入",
+        "/api/curd/page/update": {
+            "put": {
+                "description": "更新页面配置,传了updatedAt时会检查页面配置是否已经被其他人修改,已修改返回40003",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "页面配置"
                 ],
-                "summary": "导入",
+                "summary": "更新页面配置",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer+空格+Token",
+                        "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "要上传的文件",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "description": "Update Page",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.PageInfo"
+                        }
                     }
                 ],
                 "responses": {
@@ -3257,9 +3468,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/publish": {
+        "/api/curd/systemobject/add": {
             "post": {
-                "description": "发布表单版本",
+                "description": "新增",
                 "consumes": [
                     "application/json"
                 ],
@@ -3267,9 +3478,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "系统定义的对象管理"
                 ],
-                "summary": "发布表单版本",
+                "summary": "新增",
                 "parameters": [
                     {
                         "type": "string",
@@ -3279,12 +3490,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update Form",
+                        "description": "Add SystemObject",
                         "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.FormVersion"
+                            "$ref": "#/definitions/curd.SystemObjectInfo"
                         }
                     }
                 ],
@@ -3298,19 +3509,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/query": {
+        "/api/curd/systemobject/all": {
             "get": {
-                "description": "分页查询",
+                "description": "查询所有",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "系统定义的对象管理"
                 ],
-                "summary": "分页查询",
+                "summary": "查询所有",
                 "parameters": [
                     {
                         "type": "string",
@@ -3318,72 +3529,21 @@ const docTemplate = `{
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "从1开始",
-                        "name": "formIndex",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "默认每页10条",
-                        "name": "formSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "排序字段",
-                        "name": "orderField",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否倒序排序",
-                        "name": "desc",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "名称",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "表单名称",
-                        "name": "pageName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "分组",
-                        "name": "group",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "description": "IDs",
-                        "name": "ids",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.QueryFormResponse"
+                            "$ref": "#/definitions/curd.GetAllSystemObjectResponse"
                         }
                     }
                 }
             }
         },
-        "/api/form/schema": {
-            "put": {
-                "description": "更新表单Schema",
+        "/api/curd/systemobject/copy": {
+            "post": {
+                "description": "复制",
                 "consumes": [
                     "application/json"
                 ],
@@ -3391,25 +3551,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "系统定义的对象管理"
                 ],
-                "summary": "更新表单Schema",
+                "summary": "复制",
                 "parameters": [
+                    {
+                        "description": "Copy SystemObject",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "jwt token",
                         "name": "authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Update Form",
-                        "name": "account",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/curd.FormInfo"
-                        }
                     }
                 ],
                 "responses": {
@@ -3422,9 +3582,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/switch": {
-            "post": {
-                "description": "切换表单版本",
+        "/api/curd/systemobject/delete": {
+            "delete": {
+                "description": "删除",
                 "consumes": [
                     "application/json"
                 ],
@@ -3432,9 +3592,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "系统定义的对象管理"
                 ],
-                "summary": "切换表单版本",
+                "summary": "删除",
                 "parameters": [
                     {
                         "type": "string",
@@ -3444,10 +3604,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Version ID",
-                        "name": "versionID",
-                        "in": "query"
+                        "description": "Delete SystemObject",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3460,9 +3623,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/update": {
-            "put": {
-                "description": "更新表单管理",
+        "/api/curd/systemobject/detail": {
+            "get": {
+                "description": "查询明细",
                 "consumes": [
                     "application/json"
                 ],
@@ -3470,9 +3633,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "表单管理"
+                    "系统定义的对象管理"
                 ],
-                "summary": "更新表单管理",
+                "summary": "查询明细",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.GetSystemObjectDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/curd/systemobject/enable": {
+            "post": {
+                "description": "禁用/启用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统定义的对象管理"
+                ],
+                "summary": "禁用/启用",
                 "parameters": [
                     {
                         "type": "string",
@@ -3482,12 +3684,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Update Form",
+                        "description": "Enable/Disable SystemObject",
                         "name": "account",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/curd.FormInfo"
+                            "$ref": "#/definitions/curd.EnableRequest"
                         }
                     }
                 ],
@@ -3501,9 +3703,171 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/form/version/detail": {
+        "/api/curd/systemobject/query": {
             "get": {
-                "description": "查询明细",
+                "description": "分页查询",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "系统定义的对象管理"
+                ],
+                "summary": "分页查询",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "pageIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "编程语言",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "enable",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.QuerySystemObjectResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/curd/systemobject/update": {
+            "put": {
+                "description": "更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统定义的对象管理"
+                ],
+                "summary": "更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update SystemObject",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.SystemObjectInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/curd/template/copy": {
+            "post": {
+                "description": "复制",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "代码模板管理"
+                ],
+                "summary": "复制",
+                "parameters": [
+                    {
+                        "description": "Copy Template",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/add": {
+            "post": {
+                "description": "新增表单",
                 "consumes": [
                     "application/json"
                 ],
@@ -3513,15 +3877,49 @@ const docTemplate = `{
                 "tags": [
                     "表单管理"
                 ],
-                "summary": "查询明细",
+                "summary": "新增表单",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID",
-                        "name": "id",
-                        "in": "query",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
                         "required": true
                     },
+                    {
+                        "description": "Add Form",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.FormInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/all": {
+            "get": {
+                "description": "查询所有表单管理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "查询所有表单管理",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "jwt token",
@@ -3534,216 +3932,1186 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/curd.GetVersionResponse"
+                            "$ref": "#/definitions/curd.GetAllFormResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "/api/form/copy": {
+            "post": {
+                "description": "复制表单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "复制表单",
+                "parameters": [
+                    {
+                        "description": "Copy Form",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/delete": {
+            "delete": {
+                "description": "删除表单管理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "删除表单管理",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Delete Form",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.DelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/detail": {
+            "get": {
+                "description": "查询明细",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "查询明细",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.GetFormDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/export": {
+            "get": {
+                "description": "导出",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "导出",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "formIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "formSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "表单名称",
+                        "name": "pageName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "IDs",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/import": {
+            "post": {
+                "description": "导入",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "导入",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer+空格+Token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "要上传的文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/publish": {
+            "post": {
+                "description": "发布表单版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "发布表单版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Form",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.FormVersion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/query": {
+            "get": {
+                "description": "分页查询",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "分页查询",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从1开始",
+                        "name": "formIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "默认每页10条",
+                        "name": "formSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段",
+                        "name": "orderField",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否倒序排序",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "表单名称",
+                        "name": "pageName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分组",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "IDs",
+                        "name": "ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.QueryFormResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/schema": {
+            "put": {
+                "description": "更新表单Schema,传了updatedAt时会检查表单是否已经被其他人修改,已修改返回40003",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "更新表单Schema",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Form",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.FormInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/switch": {
+            "post": {
+                "description": "切换表单版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "切换表单版本",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID",
+                        "name": "versionID",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/update": {
+            "put": {
+                "description": "更新表单管理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "更新表单管理",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Form",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/curd.FormInfo"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/form/version/detail": {
+            "get": {
+                "description": "查询明细",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表单管理"
+                ],
+                "summary": "查询明细",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "jwt token",
+                        "name": "authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/curd.GetVersionResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
         "curd.CellAttrs": {
             "type": "object",
             "properties": {
                 "cellID": {
                     "type": "string"
                 },
-                "fill": {
+                "fill": {
+                    "type": "string"
+                },
+                "fontSize": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isDefaultLabel": {
+                    "type": "boolean"
+                },
+                "linkHref": {
+                    "type": "string"
+                },
+                "magnet": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "other": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "selectedFill": {
+                    "type": "string"
+                },
+                "selectedStroke": {
+                    "type": "string"
+                },
+                "stroke": {
+                    "type": "string"
+                },
+                "textAnchor": {
+                    "type": "string"
+                },
+                "textVerticalAnchor": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.CellConnecting": {
+            "type": "object",
+            "properties": {
+                "anotherCell": {
+                    "type": "string"
+                },
+                "cellID": {
+                    "type": "string"
+                },
+                "direct": {
+                    "type": "integer"
+                },
+                "edge": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.CellInfo": {
+            "type": "object",
+            "properties": {
+                "attrs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.CellAttrs"
+                    }
+                },
+                "common": {
+                    "type": "boolean"
+                },
+                "connectings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.CellConnecting"
+                    }
+                },
+                "defaultEdge": {
+                    "type": "boolean"
+                },
+                "defaultLabel": {
+                    "type": "string"
+                },
+                "defaultLabelAttrs": {
+                    "type": "string"
+                },
+                "formDefaultValue": {
+                    "description": "表单默认值,json格式",
+                    "type": "string"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "height2": {
+                    "type": "integer"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "iconSource": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idPrefix": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "isEdge": {
+                    "type": "boolean"
+                },
+                "isMust": {
+                    "description": "系统必须要有的数据",
+                    "type": "boolean"
+                },
+                "markup": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.CellMarkup"
+                    }
+                },
+                "mustSource": {
+                    "type": "boolean"
+                },
+                "mustTarget": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "other": {
+                    "description": "其他属性",
+                    "type": "string"
+                },
+                "parent": {
+                    "type": "boolean"
+                },
+                "ports": {
+                    "type": "string"
+                },
+                "projectID": {
+                    "type": "string"
+                },
+                "propertyForm": {
+                    "type": "string"
+                },
+                "resizing": {
+                    "type": "boolean"
+                },
+                "shape": {
+                    "type": "string"
+                },
+                "system": {
+                    "type": "string"
+                },
+                "tenantID": {
+                    "type": "string"
+                },
+                "view": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                },
+                "width2": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.CellMarkup": {
+            "type": "object",
+            "properties": {
+                "attrs": {
+                    "type": "string"
+                },
+                "cellID": {
+                    "type": "string"
+                },
+                "children": {
                     "type": "string"
                 },
-                "fontSize": {
+                "className": {
+                    "type": "string"
+                },
+                "groupSelector": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
                     "type": "integer"
                 },
-                "id": {
+                "isDefaultLabel": {
+                    "type": "boolean"
+                },
+                "other": {
+                    "type": "string"
+                },
+                "selector": {
+                    "type": "string"
+                },
+                "style": {
+                    "type": "string"
+                },
+                "tagName": {
+                    "type": "string"
+                },
+                "textContent": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.CommonResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.DelRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "tenantID": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.EnableRequest": {
+            "type": "object",
+            "properties": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "tenantID": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.FileTemplateInfo": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "一组模板ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dir": {
+                    "description": "文件存放的目录",
+                    "type": "string"
+                },
+                "end": {
+                    "description": "结尾模板ID",
+                    "type": "string"
+                },
+                "fileNameSuffix": {
+                    "description": "文件名称后缀",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "分组",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "description": "编程语言",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "文件模版名称",
+                    "type": "string"
+                },
+                "package": {
+                    "description": "包名",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "自定义参数",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "开头模板ID",
+                    "type": "string"
+                }
+            }
+        },
+        "curd.FormInfo": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isMust": {
+                    "description": "系统必须要有的数据",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageName": {
+                    "type": "string"
+                },
+                "projectID": {
                     "type": "string"
                 },
-                "isDefaultLabel": {
+                "public": {
                     "type": "boolean"
                 },
-                "linkHref": {
+                "schema": {
                     "type": "string"
                 },
-                "magnet": {
+                "subform": {
                     "type": "boolean"
                 },
-                "name": {
+                "tenantID": {
                     "type": "string"
                 },
-                "other": {
+                "type": {
                     "type": "string"
                 },
-                "ref": {
+                "updatedAt": {
+                    "type": "integer"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.FormVersion"
+                    }
+                }
+            }
+        },
+        "curd.FormVersion": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
-                "selectedFill": {
+                "description": {
                     "type": "string"
                 },
-                "selectedStroke": {
+                "formID": {
                     "type": "string"
                 },
-                "stroke": {
+                "id": {
                     "type": "string"
                 },
-                "textAnchor": {
+                "schema": {
                     "type": "string"
                 },
-                "textVerticalAnchor": {
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "curd.CellConnecting": {
+        "curd.FunctionalTemplateInfo": {
             "type": "object",
             "properties": {
-                "anotherCell": {
+                "description": {
+                    "description": "描述",
                     "type": "string"
                 },
-                "cellID": {
+                "fileTemplateIDs": {
+                    "description": "文件模版ID",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group": {
+                    "description": "分组",
                     "type": "string"
                 },
-                "direct": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "edge": {
+                "language": {
+                    "description": "语音",
                     "type": "string"
                 },
-                "id": {
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "自定义参数",
                     "type": "string"
                 }
             }
         },
-        "curd.CellInfo": {
+        "curd.GetAllCellResponse": {
             "type": "object",
             "properties": {
-                "attrs": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.CellAttrs"
+                        "$ref": "#/definitions/curd.CellInfo"
                     }
                 },
-                "common": {
-                    "type": "boolean"
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.GetAllFileTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "connectings": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.CellConnecting"
+                        "$ref": "#/definitions/curd.FileTemplateInfo"
                     }
                 },
-                "defaultEdge": {
-                    "type": "boolean"
-                },
-                "defaultLabel": {
-                    "type": "string"
-                },
-                "defaultLabelAttrs": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "curd.GetAllFormResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "formDefaultValue": {
-                    "description": "表单默认值,json格式",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.FormInfo"
+                    }
                 },
-                "group": {
+                "message": {
                     "type": "string"
-                },
-                "height": {
+                }
+            }
+        },
+        "curd.GetAllFunctionalTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer"
                 },
-                "height2": {
-                    "type": "integer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.FunctionalTemplateInfo"
+                    }
                 },
-                "icon": {
+                "message": {
                     "type": "string"
-                },
-                "iconSource": {
+                }
+            }
+        },
+        "curd.GetAllPageResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.PageInfo"
+                    }
                 },
-                "idPrefix": {
+                "message": {
                     "type": "string"
-                },
-                "index": {
+                }
+            }
+        },
+        "curd.GetAllSystemObjectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer"
                 },
-                "isEdge": {
-                    "type": "boolean"
-                },
-                "isMust": {
-                    "description": "系统必须要有的数据",
-                    "type": "boolean"
-                },
-                "markup": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.CellMarkup"
+                        "$ref": "#/definitions/curd.SystemObjectInfo"
                     }
                 },
-                "mustSource": {
-                    "type": "boolean"
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.GetCellDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "mustTarget": {
-                    "type": "boolean"
+                "data": {
+                    "$ref": "#/definitions/curd.CellInfo"
                 },
-                "name": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "curd.GetFileTemplateDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "other": {
-                    "description": "其他属性",
+                "data": {
+                    "$ref": "#/definitions/curd.FileTemplateInfo"
+                },
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "curd.GetFormDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "parent": {
-                    "type": "boolean"
+                "data": {
+                    "$ref": "#/definitions/curd.FormInfo"
                 },
-                "ports": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "curd.GetFunctionalTemplateDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "projectID": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/curd.FunctionalTemplateInfo"
                 },
-                "propertyForm": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "curd.GetPageDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "resizing": {
-                    "type": "boolean"
+                "data": {
+                    "$ref": "#/definitions/curd.PageInfo"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.GetSystemObjectDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "shape": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/curd.SystemObjectInfo"
                 },
-                "system": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "curd.GetVersionResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "tenantID": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/curd.FormVersion"
                 },
-                "view": {
+                "message": {
                     "type": "string"
-                },
-                "width": {
-                    "type": "integer"
-                },
-                "width2": {
-                    "type": "integer"
                 }
             }
         },
-        "curd.CellMarkup": {
+        "curd.PageButton": {
             "type": "object",
             "properties": {
-                "attrs": {
-                    "type": "string"
+                "enable": {
+                    "description": "是否启用",
+                    "type": "boolean"
                 },
-                "cellID": {
+                "expanded": {
+                    "type": "boolean"
+                },
+                "formID": {
                     "type": "string"
                 },
-                "children": {
+                "hiddenScript": {
                     "type": "string"
                 },
-                "className": {
+                "href": {
                     "type": "string"
                 },
-                "groupSelector": {
+                "hrefFunc": {
                     "type": "string"
                 },
                 "id": {
@@ -3752,895 +5120,954 @@ const docTemplate = `{
                 "index": {
                     "type": "integer"
                 },
-                "isDefaultLabel": {
-                    "type": "boolean"
-                },
-                "other": {
+                "key": {
                     "type": "string"
                 },
-                "selector": {
+                "label": {
                     "type": "string"
                 },
-                "style": {
+                "pageID": {
                     "type": "string"
                 },
-                "tagName": {
+                "permission": {
+                    "description": "按钮权限",
                     "type": "string"
                 },
-                "textContent": {
+                "script": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.CommonResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                },
+                "showPosition": {
+                    "description": "按钮显示位置，0-显示在列表行 1-在搜索框显示",
                     "type": "integer"
                 },
-                "message": {
+                "showType": {
                     "type": "string"
                 }
             }
         },
-        "curd.DelRequest": {
+        "curd.PageDataRule": {
             "type": "object",
             "properties": {
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "tenantID": {
-                    "type": "string"
-                }
-            }
-        },
-        "curd.EnableRequest": {
-            "type": "object",
-            "properties": {
                 "enable": {
+                    "description": "是否启用",
                     "type": "boolean"
                 },
                 "id": {
                     "type": "string"
                 },
-                "tenantID": {
+                "index": {
+                    "type": "integer"
+                },
+                "pageID": {
+                    "type": "string"
+                },
+                "roleID": {
+                    "description": "usercenter的角色ID,为空时对所有用户生效",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "例如owner = currentUser、dept in currentUser.depts、status != archived",
                     "type": "string"
                 }
             }
         },
-        "curd.FileTemplateInfo": {
+        "curd.PageField": {
             "type": "object",
             "properties": {
-                "body": {
-                    "description": "一组模板ID",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "align": {
+                    "description": "对齐方式",
+                    "type": "string"
                 },
-                "dir": {
-                    "description": "文件存放的目录",
+                "component": {
                     "type": "string"
                 },
-                "end": {
-                    "description": "结尾模板ID",
+                "componentProps": {
                     "type": "string"
                 },
-                "fileNameSuffix": {
-                    "description": "文件名称后缀",
+                "copyable": {
+                    "type": "boolean"
+                },
+                "dataType": {
                     "type": "string"
                 },
-                "group": {
-                    "description": "分组",
+                "ellipsis": {
+                    "type": "boolean"
+                },
+                "enableSort": {
+                    "type": "boolean"
+                },
+                "fixed": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "language": {
-                    "description": "编程语言",
+                "labelField": {
                     "type": "string"
                 },
                 "name": {
-                    "description": "文件模版名称",
                     "type": "string"
                 },
-                "package": {
-                    "description": "包名",
+                "pageID": {
                     "type": "string"
                 },
-                "params": {
-                    "description": "自定义参数",
-                    "type": "string"
+                "rowKey": {
+                    "type": "boolean"
                 },
-                "start": {
-                    "description": "开头模板ID",
-                    "type": "string"
-                }
-            }
-        },
-        "curd.FormInfo": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
+                "showInTable": {
+                    "type": "boolean"
+                },
+                "sort": {
                     "type": "integer"
                 },
-                "group": {
+                "title": {
                     "type": "string"
                 },
-                "id": {
+                "valueEnum": {
                     "type": "string"
                 },
-                "isMust": {
-                    "description": "系统必须要有的数据",
-                    "type": "boolean"
-                },
-                "name": {
+                "valueField": {
                     "type": "string"
                 },
-                "pageName": {
+                "width": {
+                    "description": "列宽度",
                     "type": "string"
-                },
-                "projectID": {
+                }
+            }
+        },
+        "curd.PageFieldPermission": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "元数据字段名",
                     "type": "string"
                 },
-                "public": {
-                    "type": "boolean"
-                },
-                "schema": {
+                "id": {
                     "type": "string"
                 },
-                "subform": {
-                    "type": "boolean"
-                },
-                "tenantID": {
+                "pageID": {
                     "type": "string"
                 },
-                "type": {
+                "permission": {
+                    "description": "hidden、readonly或者readwrite",
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "integer"
-                },
-                "versions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.FormVersion"
-                    }
+                "roleID": {
+                    "description": "usercenter的角色ID,为空时作为没有单独配置的角色的默认权限",
+                    "type": "string"
                 }
             }
         },
-        "curd.FormVersion": {
+        "curd.PageHook": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "enable": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "event": {
+                    "description": "触发时机,beforeCreate,afterCreate,beforeUpdate,afterUpdate,beforeDelete,afterDelete",
                     "type": "string"
                 },
-                "description": {
+                "expression": {
                     "type": "string"
                 },
-                "formID": {
+                "field": {
+                    "description": "Type为set时保存计算结果的字段,Type为validate时校验失败的字段",
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "schema": {
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "校验失败时的提示",
                     "type": "string"
                 },
-                "version": {
+                "pageID": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "validate-校验 set-计算字段",
                     "type": "string"
                 }
             }
         },
-        "curd.FunctionalTemplateInfo": {
+        "curd.PageInfo": {
             "type": "object",
             "properties": {
-                "description": {
-                    "description": "描述",
+                "addDefaultValue": {
                     "type": "string"
                 },
-                "fileTemplateIDs": {
-                    "description": "文件模版ID",
+                "addFormID": {
+                    "type": "string"
+                },
+                "bordered": {
+                    "type": "boolean"
+                },
+                "buttons": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/curd.PageButton"
                     }
                 },
-                "group": {
-                    "description": "分组",
+                "cardAvatarField": {
                     "type": "string"
                 },
-                "id": {
+                "cardContentField": {
                     "type": "string"
                 },
-                "language": {
-                    "description": "语音",
+                "cardDescriptionField": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "名称",
+                "cardImageField": {
                     "type": "string"
                 },
-                "params": {
-                    "description": "自定义参数",
-                    "type": "string"
-                }
-            }
-        },
-        "curd.GetAllCellResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "cardLoadType": {
                     "type": "integer"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.CellInfo"
-                    }
-                },
-                "message": {
+                "cardTitleField": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetAllFileTemplateResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                },
+                "createdAt": {
                     "type": "integer"
                 },
-                "data": {
+                "dataRules": {
+                    "description": "行级数据权限规则",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.FileTemplateInfo"
+                        "$ref": "#/definitions/curd.PageDataRule"
                     }
                 },
-                "message": {
+                "description": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetAllFormResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.FormInfo"
-                    }
+                "editFormID": {
+                    "type": "string"
                 },
-                "message": {
+                "editable": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetAllFunctionalTemplateResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "enableAudit": {
+                    "description": "是否记录数据变更历史",
+                    "type": "boolean"
+                },
+                "fieldAccess": {
+                    "description": "当前用户每个字段生效的权限,key是字段名,只在查询明细时返回,保存时忽略",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "fieldPermissions": {
+                    "description": "按角色控制字段的读写权限",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.FunctionalTemplateInfo"
+                        "$ref": "#/definitions/curd.PageFieldPermission"
                     }
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "curd.GetAllPageResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "data": {
+                "fields": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.PageInfo"
+                        "$ref": "#/definitions/curd.PageField"
                     }
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "curd.GetAllSystemObjectResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "data": {
+                "hooks": {
+                    "description": "服务端执行的钩子",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.SystemObjectInfo"
+                        "$ref": "#/definitions/curd.PageHook"
                     }
                 },
-                "message": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetCellDetailResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.CellInfo"
+                "isChild": {
+                    "description": "子列表，应用于左右分栏",
+                    "type": "boolean"
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "curd.GetFileTemplateDetailResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
+                "isMust": {
+                    "description": "系统必须要有的数据",
+                    "type": "boolean"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.FileTemplateInfo"
+                "labelField": {
+                    "type": "string"
                 },
-                "message": {
+                "listAvatarField": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetFormDetailResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.FormInfo"
+                "listContentField": {
+                    "type": "string"
                 },
-                "message": {
+                "listDescriptionField": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetFunctionalTemplateDetailResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.FunctionalTemplateInfo"
+                "listExpandable": {
+                    "type": "boolean"
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "curd.GetPageDetailResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "listGridTypeColumn": {
                     "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.PageInfo"
+                "listGridTypeGutter": {
+                    "type": "integer"
                 },
-                "message": {
+                "listItemLayout": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetSystemObjectDetailResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
+                },
+                "listKeyField": {
+                    "type": "string"
+                },
+                "listLoadType": {
                     "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.SystemObjectInfo"
+                "listTitleField": {
+                    "type": "string"
                 },
-                "message": {
+                "loadDetailAfter": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.GetVersionResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
                 },
-                "data": {
-                    "$ref": "#/definitions/curd.FormVersion"
+                "loadDetailBefore": {
+                    "type": "string"
                 },
-                "message": {
+                "metadataID": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.PageButton": {
-            "type": "object",
-            "properties": {
-                "enable": {
-                    "description": "是否启用",
-                    "type": "boolean"
                 },
-                "expanded": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "formID": {
+                "pageSize": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "description": "子列表的PageName,多个使用逗号隔开，应用于左右分栏等",
                     "type": "string"
                 },
-                "hiddenScript": {
+                "path": {
                     "type": "string"
                 },
-                "href": {
+                "proListAvatarDataIndex": {
                     "type": "string"
                 },
-                "hrefFunc": {
+                "proListAvatarRender": {
                     "type": "string"
                 },
-                "id": {
+                "proListAvatarValueType": {
                     "type": "string"
                 },
-                "index": {
-                    "type": "integer"
+                "proListCardActionProps": {
+                    "type": "string"
                 },
-                "key": {
+                "proListContentDataIndex": {
                     "type": "string"
                 },
-                "label": {
+                "proListContentRender": {
                     "type": "string"
                 },
-                "pageID": {
+                "proListContentValueType": {
                     "type": "string"
                 },
-                "permission": {
-                    "description": "按钮权限",
+                "proListExtraDataIndex": {
                     "type": "string"
                 },
-                "script": {
+                "proListExtraRender": {
                     "type": "string"
                 },
-                "showPosition": {
-                    "description": "按钮显示位置，0-显示在列表行 1-在搜索框显示",
-                    "type": "integer"
+                "proListExtraValueType": {
+                    "type": "string"
                 },
-                "showType": {
+                "proListGhost": {
+                    "type": "boolean"
+                },
+                "proListItemClick": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.PageField": {
-            "type": "object",
-            "properties": {
-                "align": {
-                    "description": "对齐方式",
+                },
+                "proListItemMouseEnter": {
                     "type": "string"
                 },
-                "component": {
+                "proListShowActions": {
                     "type": "string"
                 },
-                "componentProps": {
+                "proListShowAvatar": {
+                    "type": "boolean"
+                },
+                "proListShowContent": {
+                    "type": "boolean"
+                },
+                "proListShowExtra": {
                     "type": "string"
                 },
-                "copyable": {
+                "proListShowMetaExtra": {
                     "type": "boolean"
                 },
-                "dataType": {
-                    "type": "string"
+                "proListShowMetaType": {
+                    "type": "boolean"
                 },
-                "ellipsis": {
+                "proListShowSubTitle": {
                     "type": "boolean"
                 },
-                "enableSort": {
+                "proListShowTitle": {
                     "type": "boolean"
                 },
-                "fixed": {
+                "proListShowType": {
+                    "type": "integer"
+                },
+                "proListSubTitleDataIndex": {
                     "type": "string"
                 },
-                "id": {
+                "proListSubTitleRender": {
                     "type": "string"
                 },
-                "labelField": {
+                "proListSubTitleValueType": {
                     "type": "string"
                 },
-                "name": {
+                "proListTitleDataIndex": {
                     "type": "string"
                 },
-                "pageID": {
+                "proListTitleRender": {
                     "type": "string"
                 },
-                "rowKey": {
-                    "type": "boolean"
+                "proListTitleValueType": {
+                    "type": "string"
                 },
-                "showInTable": {
-                    "type": "boolean"
+                "proListTypeDataIndex": {
+                    "type": "string"
                 },
-                "sort": {
-                    "type": "integer"
+                "proListTypeRender": {
+                    "type": "string"
                 },
-                "title": {
+                "proListTypeValueType": {
                     "type": "string"
                 },
-                "valueEnum": {
+                "projectID": {
                     "type": "string"
                 },
-                "valueField": {
+                "queryAfter": {
                     "type": "string"
                 },
-                "width": {
-                    "description": "列宽度",
+                "queryBefore": {
                     "type": "string"
-                }
-            }
-        },
-        "curd.PageInfo": {
-            "type": "object",
-            "properties": {
-                "addDefaultValue": {
+                },
+                "scrollX": {
+                    "type": "integer"
+                },
+                "searchDefaultValue": {
                     "type": "string"
                 },
-                "addFormID": {
+                "searchFormID": {
                     "type": "string"
                 },
-                "bordered": {
+                "showIndex": {
                     "type": "boolean"
                 },
-                "buttons": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.PageButton"
-                    }
+                "showSelection": {
+                    "type": "boolean"
                 },
-                "cardAvatarField": {
+                "submitAfter": {
                     "type": "string"
                 },
-                "cardContentField": {
+                "submitBefore": {
                     "type": "string"
                 },
-                "cardDescriptionField": {
+                "tenantID": {
                     "type": "string"
                 },
-                "cardImageField": {
+                "title": {
                     "type": "string"
                 },
-                "cardLoadType": {
+                "toolBar": {
+                    "$ref": "#/definitions/curd.PageToolBar"
+                },
+                "type": {
                     "type": "integer"
                 },
-                "cardTitleField": {
+                "updatedAt": {
+                    "type": "integer"
+                },
+                "valueField": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "integer"
+                "viewFormID": {
+                    "type": "string"
                 },
-                "description": {
+                "webhooks": {
+                    "description": "记录新增、修改、删除后推送的地址",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.PageWebhook"
+                    }
+                }
+            }
+        },
+        "curd.PageToolBar": {
+            "type": "object",
+            "properties": {
+                "addPermission": {
+                    "description": "新增按钮权限",
                     "type": "string"
                 },
-                "editFormID": {
+                "addScript": {
+                    "description": "点击新增按钮时执行脚本",
                     "type": "string"
                 },
-                "editable": {
+                "batchDelUri": {
                     "type": "string"
                 },
-                "enable": {
-                    "type": "boolean"
+                "exportUri": {
+                    "description": "导出API接口地址",
+                    "type": "string"
                 },
-                "fields": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.PageField"
-                    }
+                "fullScreen": {
+                    "description": "是否显示全屏按钮",
+                    "type": "boolean"
                 },
                 "id": {
                     "type": "string"
                 },
-                "isChild": {
-                    "description": "子列表，应用于左右分栏",
-                    "type": "boolean"
+                "importFormID": {
+                    "type": "string"
                 },
-                "isMust": {
-                    "description": "系统必须要有的数据",
+                "importMaxCount": {
+                    "type": "integer"
+                },
+                "importMulti": {
                     "type": "boolean"
                 },
-                "labelField": {
+                "importUri": {
                     "type": "string"
                 },
-                "listAvatarField": {
+                "pageID": {
                     "type": "string"
                 },
-                "listContentField": {
-                    "type": "string"
+                "reload": {
+                    "description": "是否显示刷新按钮",
+                    "type": "boolean"
                 },
-                "listDescriptionField": {
+                "render": {
                     "type": "string"
                 },
-                "listExpandable": {
+                "rowSelection": {
+                    "description": "是否显示可选框",
                     "type": "boolean"
                 },
-                "listGridTypeColumn": {
-                    "type": "integer"
+                "setting": {
+                    "description": "是否显示设置按钮",
+                    "type": "boolean"
                 },
-                "listGridTypeGutter": {
-                    "type": "integer"
+                "showAdd": {
+                    "description": "是否显示新增按钮",
+                    "type": "boolean"
                 },
-                "listItemLayout": {
-                    "type": "string"
+                "showBatchDel": {
+                    "description": "是否显示批量删除按钮",
+                    "type": "boolean"
                 },
-                "listKeyField": {
-                    "type": "string"
+                "showExport": {
+                    "description": "是否显示导出按钮",
+                    "type": "boolean"
                 },
-                "listLoadType": {
-                    "type": "integer"
+                "showImport": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "curd.PageWebhook": {
+            "type": "object",
+            "properties": {
+                "enable": {
+                    "description": "是否启用",
+                    "type": "boolean"
                 },
-                "listTitleField": {
+                "events": {
+                    "description": "create,update,delete,多个使用逗号隔开,为空时推送所有事件",
                     "type": "string"
                 },
-                "loadDetailAfter": {
+                "id": {
                     "type": "string"
                 },
-                "loadDetailBefore": {
+                "name": {
                     "type": "string"
                 },
-                "metadataID": {
+                "pageID": {
                     "type": "string"
                 },
-                "name": {
+                "secret": {
+                    "description": "用于HMAC-SHA256签名,为空时不签名",
                     "type": "string"
                 },
-                "pageSize": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "curd.QueryCellResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer"
                 },
-                "pages": {
-                    "description": "子列表的PageName,多个使用逗号隔开，应用于左右分栏等",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.CellInfo"
+                    }
                 },
-                "path": {
+                "message": {
                     "type": "string"
                 },
-                "proListAvatarDataIndex": {
-                    "type": "string"
+                "pages": {
+                    "type": "integer"
                 },
-                "proListAvatarRender": {
-                    "type": "string"
+                "records": {
+                    "type": "integer"
                 },
-                "proListAvatarValueType": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.QueryFileTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "proListCardActionProps": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.FileTemplateInfo"
+                    }
                 },
-                "proListContentDataIndex": {
+                "message": {
                     "type": "string"
                 },
-                "proListContentRender": {
-                    "type": "string"
+                "pages": {
+                    "type": "integer"
                 },
-                "proListContentValueType": {
-                    "type": "string"
+                "records": {
+                    "type": "integer"
                 },
-                "proListExtraDataIndex": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.QueryFormResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.FormInfo"
+                    }
                 },
-                "proListExtraRender": {
+                "message": {
                     "type": "string"
                 },
-                "proListExtraValueType": {
-                    "type": "string"
+                "pages": {
+                    "type": "integer"
                 },
-                "proListGhost": {
-                    "type": "boolean"
+                "records": {
+                    "type": "integer"
                 },
-                "proListItemClick": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.QueryFunctionalTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "proListItemMouseEnter": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.FunctionalTemplateInfo"
+                    }
                 },
-                "proListShowActions": {
+                "message": {
                     "type": "string"
                 },
-                "proListShowAvatar": {
-                    "type": "boolean"
-                },
-                "proListShowContent": {
-                    "type": "boolean"
+                "pages": {
+                    "type": "integer"
                 },
-                "proListShowExtra": {
-                    "type": "string"
+                "records": {
+                    "type": "integer"
                 },
-                "proListShowMetaExtra": {
-                    "type": "boolean"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.QueryPageResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "proListShowMetaType": {
-                    "type": "boolean"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.PageInfo"
+                    }
                 },
-                "proListShowSubTitle": {
-                    "type": "boolean"
+                "message": {
+                    "type": "string"
                 },
-                "proListShowTitle": {
-                    "type": "boolean"
+                "pages": {
+                    "type": "integer"
                 },
-                "proListShowType": {
+                "records": {
                     "type": "integer"
                 },
-                "proListSubTitleDataIndex": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.QuerySystemObjectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "proListSubTitleRender": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/curd.SystemObjectInfo"
+                    }
                 },
-                "proListSubTitleValueType": {
+                "message": {
                     "type": "string"
                 },
-                "proListTitleDataIndex": {
-                    "type": "string"
+                "pages": {
+                    "type": "integer"
                 },
-                "proListTitleRender": {
-                    "type": "string"
+                "records": {
+                    "type": "integer"
                 },
-                "proListTitleValueType": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "curd.SystemObjectInfo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "代码",
                     "type": "string"
                 },
-                "proListTypeDataIndex": {
+                "description": {
+                    "description": "备注",
                     "type": "string"
                 },
-                "proListTypeRender": {
+                "displayName": {
+                    "description": "显示名称",
                     "type": "string"
                 },
-                "proListTypeValueType": {
-                    "type": "string"
+                "enable": {
+                    "description": "是否启用",
+                    "type": "boolean"
                 },
-                "projectID": {
+                "id": {
                     "type": "string"
                 },
-                "queryAfter": {
+                "language": {
+                    "description": "编程语言",
                     "type": "string"
                 },
-                "queryBefore": {
+                "name": {
+                    "description": "名称",
                     "type": "string"
                 },
-                "scrollX": {
+                "type": {
+                    "description": "类型 1-类 2-结构体 3-方法 4-代码",
                     "type": "integer"
-                },
-                "searchDefaultValue": {
-                    "type": "string"
-                },
-                "searchFormID": {
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
                     "type": "string"
                 },
-                "showIndex": {
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
                     "type": "boolean"
+                }
+            }
+        },
+        "http.AddRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "model.AggregateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
                 },
-                "showSelection": {
-                    "type": "boolean"
+                "current": {
+                    "type": "integer"
                 },
-                "submitAfter": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
                 },
-                "submitBefore": {
-                    "type": "string"
+                "desc": {
+                    "type": "boolean"
                 },
-                "tenantID": {
+                "message": {
                     "type": "string"
                 },
-                "title": {
+                "orderField": {
                     "type": "string"
                 },
-                "toolBar": {
-                    "$ref": "#/definitions/curd.PageToolBar"
+                "pageIndex": {
+                    "type": "integer"
                 },
-                "type": {
+                "pageSize": {
                     "type": "integer"
                 },
-                "updatedAt": {
+                "pages": {
                     "type": "integer"
                 },
-                "valueField": {
-                    "type": "string"
+                "records": {
+                    "type": "integer"
                 },
-                "viewFormID": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "curd.PageToolBar": {
+        "model.AuditLog": {
             "type": "object",
             "properties": {
-                "addPermission": {
-                    "description": "新增按钮权限",
-                    "type": "string"
-                },
-                "addScript": {
-                    "description": "点击新增按钮时执行脚本",
+                "action": {
                     "type": "string"
                 },
-                "batchDelUri": {
-                    "type": "string"
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldChange"
+                    }
                 },
-                "exportUri": {
-                    "description": "导出API接口地址",
+                "createdAt": {
                     "type": "string"
                 },
-                "fullScreen": {
-                    "description": "是否显示全屏按钮",
-                    "type": "boolean"
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
                 },
                 "id": {
                     "type": "string"
                 },
-                "importFormID": {
+                "pageName": {
                     "type": "string"
                 },
-                "importMaxCount": {
-                    "type": "integer"
-                },
-                "importMulti": {
-                    "type": "boolean"
-                },
-                "importUri": {
+                "recordID": {
                     "type": "string"
                 },
-                "pageID": {
+                "tenantID": {
                     "type": "string"
                 },
-                "reload": {
-                    "description": "是否显示刷新按钮",
-                    "type": "boolean"
+                "transID": {
+                    "type": "string"
                 },
-                "render": {
+                "updatedAt": {
                     "type": "string"
                 },
-                "rowSelection": {
-                    "description": "是否显示可选框",
-                    "type": "boolean"
+                "userID": {
+                    "type": "string"
                 },
-                "setting": {
-                    "description": "是否显示设置按钮",
-                    "type": "boolean"
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BatchFailure": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
                 },
-                "showAdd": {
-                    "description": "是否显示新增按钮",
-                    "type": "boolean"
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BatchRequest": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "showBatchDel": {
-                    "description": "是否显示批量删除按钮",
+                "enable": {
                     "type": "boolean"
                 },
-                "showExport": {
-                    "description": "是否显示导出按钮",
-                    "type": "boolean"
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "showImport": {
-                    "type": "boolean"
+                "pageName": {
+                    "type": "string"
                 }
             }
         },
-        "curd.QueryCellResponse": {
+        "model.BatchResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer"
                 },
-                "data": {
+                "current": {
+                    "type": "integer"
+                },
+                "desc": {
+                    "type": "boolean"
+                },
+                "failures": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.CellInfo"
+                        "$ref": "#/definitions/model.BatchFailure"
                     }
                 },
                 "message": {
                     "type": "string"
                 },
+                "orderField": {
+                    "type": "string"
+                },
+                "pageIndex": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
                 "pages": {
                     "type": "integer"
                 },
@@ -4652,21 +6079,31 @@ const docTemplate = `{
                 }
             }
         },
-        "curd.QueryFileTemplateResponse": {
+        "model.CommonDetailResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.FileTemplateInfo"
-                    }
+                "current": {
+                    "type": "integer"
+                },
+                "data": {},
+                "desc": {
+                    "type": "boolean"
                 },
                 "message": {
                     "type": "string"
                 },
+                "orderField": {
+                    "type": "string"
+                },
+                "pageIndex": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
                 "pages": {
                     "type": "integer"
                 },
@@ -4678,21 +6115,30 @@ const docTemplate = `{
                 }
             }
         },
-        "curd.QueryFormResponse": {
+        "model.CommonResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.FormInfo"
-                    }
+                "current": {
+                    "type": "integer"
+                },
+                "desc": {
+                    "type": "boolean"
                 },
                 "message": {
                     "type": "string"
                 },
+                "orderField": {
+                    "type": "string"
+                },
+                "pageIndex": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
                 "pages": {
                     "type": "integer"
                 },
@@ -4704,21 +6150,46 @@ const docTemplate = `{
                 }
             }
         },
-        "curd.QueryFunctionalTemplateResponse": {
+        "model.FieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {},
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HistoryResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer"
                 },
+                "current": {
+                    "type": "integer"
+                },
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.FunctionalTemplateInfo"
+                        "$ref": "#/definitions/model.AuditLog"
                     }
                 },
+                "desc": {
+                    "type": "boolean"
+                },
                 "message": {
                     "type": "string"
                 },
+                "orderField": {
+                    "type": "string"
+                },
+                "pageIndex": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
                 "pages": {
                     "type": "integer"
                 },
@@ -4730,104 +6201,120 @@ const docTemplate = `{
                 }
             }
         },
-        "curd.QueryPageResponse": {
+        "model.ImportResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer"
                 },
+                "current": {
+                    "type": "integer"
+                },
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/curd.PageInfo"
+                        "$ref": "#/definitions/model.ImportRowResult"
                     }
                 },
+                "desc": {
+                    "type": "boolean"
+                },
+                "failureCount": {
+                    "type": "integer"
+                },
                 "message": {
                     "type": "string"
                 },
+                "orderField": {
+                    "type": "string"
+                },
+                "pageIndex": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
                 "pages": {
                     "type": "integer"
                 },
                 "records": {
                     "type": "integer"
                 },
+                "successCount": {
+                    "type": "integer"
+                },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "curd.QuerySystemObjectResponse": {
+        "model.ImportRowResult": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "errors": {
+                    "$ref": "#/definitions/model.ValidationErrors"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/curd.SystemObjectInfo"
-                    }
+                "file": {
+                    "type": "string"
                 },
                 "message": {
                     "type": "string"
                 },
-                "pages": {
-                    "type": "integer"
-                },
-                "records": {
+                "row": {
                     "type": "integer"
                 },
-                "total": {
-                    "type": "integer"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "curd.SystemObjectInfo": {
+        "model.QueryResponse": {
             "type": "object",
             "properties": {
                 "code": {
-                    "description": "代码",
-                    "type": "string"
+                    "type": "integer"
                 },
-                "description": {
-                    "description": "备注",
-                    "type": "string"
+                "current": {
+                    "type": "integer"
                 },
-                "displayName": {
-                    "description": "显示名称",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
                 },
-                "enable": {
-                    "description": "是否启用",
+                "desc": {
                     "type": "boolean"
                 },
-                "id": {
+                "message": {
                     "type": "string"
                 },
-                "language": {
-                    "description": "编程语言",
+                "nextCursor": {
+                    "description": "NextCursor 游标分页时下一页的位置,没有下一页时为空",
                     "type": "string"
                 },
-                "name": {
-                    "description": "名称",
+                "orderField": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "类型 1-类 2-结构体 3-方法 4-代码",
+                "pageIndex": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "http.AddRequest": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "object",
-                    "additionalProperties": true
-                }
-            }
-        },
-        "model.CommonDetailResponse": {
+        "model.SaveResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -4836,10 +6323,12 @@ const docTemplate = `{
                 "current": {
                     "type": "integer"
                 },
-                "data": {},
                 "desc": {
                     "type": "boolean"
                 },
+                "errors": {
+                    "$ref": "#/definitions/model.ValidationErrors"
+                },
                 "message": {
                     "type": "string"
                 },
@@ -4863,7 +6352,87 @@ const docTemplate = `{
                 }
             }
         },
-        "model.QueryResponse": {
+        "model.TreeMoveRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "pageName": {
+                    "type": "string"
+                },
+                "parentID": {
+                    "description": "ParentID 新的父节点,为空时移动到根节点",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ValidationErrors": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "model.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextRetryAt": {
+                    "type": "string"
+                },
+                "pageName": {
+                    "type": "string"
+                },
+                "recordID": {
+                    "type": "string"
+                },
+                "responseCode": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenantID": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhookID": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookDeliveryResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -4875,8 +6444,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "additionalProperties": true
+                        "$ref": "#/definitions/model.WebhookDelivery"
                     }
                 },
                 "desc": {
@@ -2,10 +2,14 @@ package http
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 
 	curdmodel "github.com/CloudSilk/curd/model"
 	apipb "github.com/CloudSilk/curd/proto"
+	"github.com/CloudSilk/pkg/constants"
 	"github.com/CloudSilk/pkg/model"
 	"github.com/CloudSilk/pkg/utils/log"
 	"github.com/CloudSilk/usercenter/utils/middleware"
@@ -18,20 +22,23 @@ type AddRequest struct {
 
 // Add godoc
 // @Summary 新增
-// @Description 新增
+// @Description 新增,子表字段(数组)中的记录会在同一个事务中新增
+// @Description 页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚
 // @Tags 通用增删改查接口
 // @Accept  json
 // @Produce  json
 // @Param pageName path string true "页面配置名称"
 // @Param authorization header string true "jwt token"
 // @Param data body AddRequest true "Add Object"
-// @Success 200 {object} apipb.CommonResponse
+// @Success 200 {object} curdmodel.SaveResponse
 // @Router /api/curd/common/{pageName}/add [post]
 func Add(c *gin.Context) {
 	transID := middleware.GetTransID(c)
 	req := &AddRequest{}
-	resp := &apipb.CommonResponse{
-		Code: apipb.Code_Success,
+	resp := &curdmodel.SaveResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
 	}
 	pageName := c.Param("pageName")
 	if pageName == "" {
@@ -55,30 +62,33 @@ func Add(c *gin.Context) {
 		c.JSON(http.StatusOK, resp)
 		return
 	}
-	err = curdmodel.Create(pageName, req.Data)
+	err = curdmodel.Create(getOperator(c), pageName, req.Data)
 	if err != nil {
-		resp.Code = model.InternalServerError
-		resp.Message = err.Error()
+		resp.SetError(err)
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
 // Update godoc
 // @Summary 更新
-// @Description 更新
+// @Description 更新,传了version或者updatedAt时会检查记录是否已经被其他人修改,已修改返回40003
+// @Description 传了子表字段(数组)时,有id的子表记录更新,没有id的新增,不在数组中的删除
+// @Description 页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚
 // @Tags 通用增删改查接口
 // @Accept  json
 // @Produce  json
 // @Param pageName path string true "页面配置名称"
 // @Param authorization header string true "jwt token"
 // @Param data body AddRequest true "Update Object"
-// @Success 200 {object} apipb.CommonResponse
+// @Success 200 {object} curdmodel.SaveResponse
 // @Router /api/curd/common/{pageName}/update [put]
 func Update(c *gin.Context) {
 	transID := middleware.GetTransID(c)
 	req := &AddRequest{}
-	resp := &apipb.CommonResponse{
-		Code: apipb.Code_Success,
+	resp := &curdmodel.SaveResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
 	}
 	pageName := c.Param("pageName")
 	if pageName == "" {
@@ -102,10 +112,58 @@ func Update(c *gin.Context) {
 		c.JSON(http.StatusOK, resp)
 		return
 	}
-	err = curdmodel.Update(pageName, req.Data)
+	err = curdmodel.Update(getOperator(c), pageName, req.Data)
 	if err != nil {
-		resp.Code = model.InternalServerError
+		resp.SetError(err)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Patch godoc
+// @Summary 部分更新
+// @Description 只更新传入的字段,没有传入的字段保持不变,和原记录合并后再校验
+// @Description 页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body AddRequest true "Patch Object"
+// @Success 200 {object} curdmodel.SaveResponse
+// @Router /api/curd/common/{pageName}/patch [patch]
+func Patch(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &AddRequest{}
+	resp := &curdmodel.SaveResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
 		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	err = middleware.Validate.Struct(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	err = curdmodel.Patch(getOperator(c), pageName, req.Data)
+	if err != nil {
+		resp.SetError(err)
 	}
 	c.JSON(http.StatusOK, resp)
 }
@@ -113,6 +171,7 @@ func Update(c *gin.Context) {
 // Delete godoc
 // @Summary 删除
 // @Description 删除
+// @Description 页面配置的服务端钩子在同一个事务中执行,钩子中止时返回40000并回滚
 // @Tags 通用增删改查接口
 // @Accept  json
 // @Produce  json
@@ -149,9 +208,9 @@ func Delete(c *gin.Context) {
 		c.JSON(http.StatusOK, resp)
 		return
 	}
-	err = curdmodel.Delete(pageName, req.Id)
+	err = curdmodel.Delete(getOperator(c), pageName, req.Id)
 	if err != nil {
-		resp.Code = model.InternalServerError
+		resp.Code = curdmodel.ErrorCode(err, apipb.Code_InternalServerError)
 		resp.Message = err.Error()
 	}
 	c.JSON(http.StatusOK, resp)
@@ -196,9 +255,9 @@ func Enable(c *gin.Context) {
 		c.JSON(http.StatusOK, resp)
 		return
 	}
-	err = curdmodel.Enable(pageName, req.Id, req.Enable)
+	err = curdmodel.Enable(getOperator(c), pageName, req.Id, req.Enable)
 	if err != nil {
-		resp.Code = model.InternalServerError
+		resp.Code = curdmodel.ErrorCode(err, apipb.Code_InternalServerError)
 		resp.Message = err.Error()
 	}
 	c.JSON(http.StatusOK, resp)
@@ -216,6 +275,14 @@ func Enable(c *gin.Context) {
 // @Param pageSize query int false "默认每页10条"
 // @Param orderField query string false "排序字段"
 // @Param desc query bool false "是否倒序排序"
+// @Param data query string false "查询条件,JSON格式,字段名可以加操作符后缀:__eq,__ne,__gt,__gte,__lt,__lte,__like,__in,__nin,__isnull,__between,例如{\"age__gte\":18,\"status__in\":[1,2]}"
+// @Param allTenants query bool false "平台租户是否查询所有租户的数据"
+// @Param expand query string false "需要展开的引用字段,多个使用逗号隔开,使用.展开下一层,最多3层,例如role,dept.parent"
+// @Param expandMode query string false "展开方式,object返回完整记录(默认),label只返回LabelField"
+// @Param useCursor query bool false "使用游标分页,按照排序字段和id定位下一页,适合数据量大的表"
+// @Param cursor query string false "上一页返回的nextCursor,传了cursor时自动使用游标分页"
+// @Param skipCount query bool false "不查询总记录数"
+// @Param keyword query string false "关键字,在所有开启了Like的字段中模糊查询"
 // @Success 200 {object} curdmodel.QueryResponse
 // @Router /api/curd/common/{pageName}/query [get]
 func Query(c *gin.Context) {
@@ -240,11 +307,55 @@ func Query(c *gin.Context) {
 		return
 	}
 	req.PageName = pageName
+	req.Operator = getOperator(c)
 	curdmodel.Query(req, resp)
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// Aggregate godoc
+// @Summary 分组统计
+// @Description 按照分组字段统计记录数量、合计、平均值、最小值和最大值,查询条件和分页查询一致
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data query string false "查询条件,JSON格式,和分页查询一致"
+// @Param groupBy query string false "分组字段,多个使用逗号隔开,日期字段可以加:day、:week、:month按天、周、月分组,例如status,createdAt:month"
+// @Param metrics query string false "统计函数,多个使用逗号隔开,格式为函数:字段,支持count、sum、avg、min、max,例如count,sum:amount,默认为count"
+// @Param allTenants query bool false "平台租户是否查询所有租户的数据"
+// @Param keyword query string false "关键字,在所有开启了Like的字段中模糊查询"
+// @Success 200 {object} curdmodel.AggregateResponse
+// @Router /api/curd/common/{pageName}/aggregate [get]
+func Aggregate(c *gin.Context) {
+	req := &curdmodel.AggregateRequest{}
+	resp := &curdmodel.AggregateResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "请求参数无效:PageName为空")
+		return
+	}
+	err := c.BindQuery(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.Aggregate(req, resp)
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // GetAll godoc
 // @Summary 查询所有
 // @Description 查询所有
@@ -268,7 +379,7 @@ func GetAll(c *gin.Context) {
 		return
 	}
 
-	data, err := curdmodel.GetAll(pageName)
+	data, err := curdmodel.GetAll(getOperator(c), pageName)
 	if err != nil {
 		resp.Code = model.InternalServerError
 		resp.Message = err.Error()
@@ -289,6 +400,8 @@ func GetAll(c *gin.Context) {
 // @Produce  json
 // @Param pageName path string true "页面配置名称"
 // @Param id query string true "ID"
+// @Param expand query string false "需要展开的引用字段,多个使用逗号隔开,使用.展开下一层"
+// @Param expandMode query string false "展开方式,object返回完整记录(默认),label只返回LabelField"
 // @Param authorization header string true "jwt token"
 // @Success 200 {object} model.CommonDetailResponse
 // @Router /api/curd/common/{pageName}/detail [get]
@@ -312,7 +425,7 @@ func GetDetail(c *gin.Context) {
 	}
 	var err error
 
-	resp.Data, err = curdmodel.GetDetailById(pageName, idStr)
+	resp.Data, err = curdmodel.GetDetailById(getOperator(c), pageName, idStr, c.Query("expand"), c.Query("expandMode"))
 	if err != nil {
 		resp.Code = model.InternalServerError
 		resp.Message = err.Error()
@@ -328,6 +441,8 @@ func GetDetail(c *gin.Context) {
 // @Produce  json
 // @Param pageName path string true "页面配置名称"
 // @Param name query string true "名称"
+// @Param expand query string false "需要展开的引用字段,多个使用逗号隔开,使用.展开下一层"
+// @Param expandMode query string false "展开方式,object返回完整记录(默认),label只返回LabelField"
 // @Param authorization header string true "jwt token"
 // @Success 200 {object} model.CommonDetailResponse
 // @Router /api/curd/common/{pageName}/detail/name [get]
@@ -350,7 +465,7 @@ func GetDetailByName(c *gin.Context) {
 		return
 	}
 	var err error
-	resp.Data, err = curdmodel.GetDetailByName(pageName, name)
+	resp.Data, err = curdmodel.GetDetailByName(getOperator(c), pageName, name, c.Query("expand"), c.Query("expandMode"))
 	if err != nil {
 		resp.Code = model.InternalServerError
 		resp.Message = err.Error()
@@ -391,7 +506,7 @@ func Copy(c *gin.Context) {
 		return
 	}
 
-	err = curdmodel.Copy(pageName, req.Id)
+	err = curdmodel.Copy(getOperator(c), pageName, req.Id)
 	if err != nil {
 		resp.Code = model.InternalServerError
 		resp.Message = err.Error()
@@ -422,26 +537,678 @@ func GetTree(c *gin.Context) {
 		return
 	}
 	var err error
-	resp.Data, resp.Records, err = curdmodel.GetTree(pageName)
+	resp.Data, resp.Records, err = curdmodel.GetTree(getOperator(c), pageName)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// TreeChildren godoc
+// @Summary 树形数据子节点
+// @Description 查询直接子节点,用于树的懒加载,isLeaf表示是否没有子节点
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param parentID query string false "父节点ID,为空时查询根节点"
+// @Success 200 {object} curdmodel.QueryResponse
+// @Router /api/curd/common/{pageName}/tree/children [get]
+func TreeChildren(c *gin.Context) {
+	resp := &curdmodel.QueryResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	var err error
+	resp.Data, err = curdmodel.TreeChildren(getOperator(c), pageName, c.Query("parentID"))
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+	resp.Records = int64(len(resp.Data))
+	c.JSON(http.StatusOK, resp)
+}
+
+// TreePath godoc
+// @Summary 树形数据路径
+// @Description 查询从根节点到指定节点的所有节点,用于面包屑导航
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param id query string true "节点ID"
+// @Success 200 {object} curdmodel.QueryResponse
+// @Router /api/curd/common/{pageName}/tree/path [get]
+func TreePath(c *gin.Context) {
+	resp := &curdmodel.QueryResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	id := c.Query("id")
+	if id == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	var err error
+	resp.Data, err = curdmodel.TreePath(getOperator(c), pageName, id)
 	if err != nil {
 		resp.Code = model.InternalServerError
 		resp.Message = err.Error()
 	}
+	resp.Records = int64(len(resp.Data))
+	c.JSON(http.StatusOK, resp)
+}
+
+// TreeMove godoc
+// @Summary 移动树形节点
+// @Description 修改节点的父节点,重新计算节点和所有子节点的level,不能移动到自己或者自己的子节点下面
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body curdmodel.TreeMoveRequest true "Move Tree Node"
+// @Success 200 {object} model.CommonResponse
+// @Router /api/curd/common/{pageName}/tree/move [post]
+func TreeMove(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.TreeMoveRequest{}
+	resp := &model.CommonResponse{
+		Code: model.Success,
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	err = middleware.Validate.Struct(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	err = curdmodel.MoveTreeNode(req)
+	if errors.Is(err, curdmodel.ErrTreeCycle) {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+	} else if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// BatchDelete godoc
+// @Summary 批量删除
+// @Description 批量删除
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body curdmodel.BatchRequest true "Batch Delete"
+// @Success 200 {object} curdmodel.BatchResponse
+// @Router /api/curd/common/{pageName}/batch/delete [delete]
+func BatchDelete(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.BatchRequest{}
+	resp := &curdmodel.BatchResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.BatchDelete(req, resp)
+	if len(resp.Failures) > 0 {
+		log.Warnf(context.Background(), "TransID:%s,批量删除失败:%s", transID, resp.Message)
+	}
 	c.JSON(http.StatusOK, resp)
 }
 
+// BatchEnable godoc
+// @Summary 批量禁用/启用
+// @Description 批量禁用/启用
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body curdmodel.BatchRequest true "Batch Enable/Disable"
+// @Success 200 {object} curdmodel.BatchResponse
+// @Router /api/curd/common/{pageName}/batch/enable [post]
+func BatchEnable(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.BatchRequest{}
+	resp := &curdmodel.BatchResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.BatchEnable(req, resp)
+	if len(resp.Failures) > 0 {
+		log.Warnf(context.Background(), "TransID:%s,批量禁用/启用失败:%s", transID, resp.Message)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// BatchUpdate godoc
+// @Summary 批量更新
+// @Description 批量更新
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body curdmodel.BatchRequest true "Batch Update"
+// @Success 200 {object} curdmodel.BatchResponse
+// @Router /api/curd/common/{pageName}/batch/update [put]
+func BatchUpdate(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.BatchRequest{}
+	resp := &curdmodel.BatchResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.BatchUpdate(req, resp)
+	if len(resp.Failures) > 0 {
+		log.Warnf(context.Background(), "TransID:%s,批量更新失败:%s", transID, resp.Message)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Export godoc
+// @Summary 导出
+// @Description 按查询条件导出数据,支持csv和xlsx两种格式
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  octet-stream
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param format query string false "导出格式:csv或者xlsx,默认csv"
+// @Param orderField query string false "排序字段"
+// @Param desc query bool false "是否倒序排序"
+// @Param data query string false "查询条件,JSON格式,和分页查询的查询条件一致"
+// @Param keyword query string false "关键字,在所有开启了Like的字段中模糊查询"
+// @Description 文本以=、+、-、@开头时前面加上单引号,避免在Excel中被当成公式
+// @Description csv输出过程中出错时最后一行是"#导出失败:"和错误信息
+// @Success 200 {file} file
+// @Failure 200 {object} apipb.CommonResponse
+// @Router /api/curd/common/{pageName}/export [get]
+func Export(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.QueryRequest{}
+	resp := &apipb.CommonResponse{
+		Code: apipb.Code_Success,
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindQuery(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	format := c.DefaultQuery("format", curdmodel.ExportFormatCSV)
+	if format != curdmodel.ExportFormatCSV && format != curdmodel.ExportFormatXLSX {
+		resp.Code = model.BadRequest
+		resp.Message = "不支持的导出格式:" + format
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	exporter, err := curdmodel.NewExporter(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == curdmodel.ExportFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w := &exportWriter{c: c, contentType: contentType, fileName: exporter.FileName(format)}
+	err = exporter.Write(format, w)
+	if err != nil {
+		log.Errorf(context.Background(), "TransID:%s,导出%s失败:%v", transID, pageName, err)
+		// 还没有输出文件内容时返回错误信息
+		if !w.written {
+			resp.Code = model.InternalServerError
+			resp.Message = err.Error()
+			c.JSON(http.StatusOK, resp)
+		}
+	}
+}
+
+// exportWriter 第一次写入时才设置文件下载的响应头
+type exportWriter struct {
+	c           *gin.Context
+	contentType string
+	fileName    string
+	written     bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.written = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(w.fileName)))
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// Import godoc
+// @Summary 导入
+// @Description 导入csv或者xlsx文件,表头可以是页面字段的标题或者元数据字段的显示名称,返回每一行的导入结果
+// @Tags 通用增删改查接口
+// @Accept  mpfd
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param files formData file true "要导入的文件,工具栏开启了导入多个文件时可以上传多个,最多上传导入最大数量个"
+// @Param transaction formData bool false "为true时所有数据都导入成功才提交"
+// @Success 200 {object} curdmodel.ImportResponse
+// @Router /api/curd/common/{pageName}/import [post]
+func Import(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	resp := &curdmodel.ImportResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	fileHeaders := form.File["files"]
+	if len(fileHeaders) == 0 {
+		resp.Code = model.BadRequest
+		resp.Message = "请上传要导入的文件"
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	var files []*curdmodel.ImportFile
+	for _, fileHeader := range fileHeaders {
+		file, err := fileHeader.Open()
+		if err != nil {
+			resp.Code = model.BadRequest
+			resp.Message = err.Error()
+			c.JSON(http.StatusOK, resp)
+			return
+		}
+		defer file.Close()
+		files = append(files, &curdmodel.ImportFile{Name: fileHeader.Filename, Reader: file})
+	}
+	req := &curdmodel.ImportRequest{
+		PageName:    pageName,
+		Operator:    getOperator(c),
+		Transaction: c.PostForm("transaction") == "true",
+	}
+	curdmodel.Import(req, files, resp)
+	if resp.FailureCount > 0 {
+		log.Warnf(context.Background(), "TransID:%s,导入%s失败%d行", transID, pageName, resp.FailureCount)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Trash godoc
+// @Summary 回收站
+// @Description 分页查询已经删除的记录,元数据需要有DeletedAt字段
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param pageIndex query int false "从1开始"
+// @Param pageSize query int false "默认每页10条"
+// @Param orderField query string false "排序字段"
+// @Param desc query bool false "是否倒序排序"
+// @Param data query string false "查询条件,JSON格式,和分页查询的查询条件一致"
+// @Success 200 {object} curdmodel.QueryResponse
+// @Router /api/curd/common/{pageName}/trash [get]
+func Trash(c *gin.Context) {
+	req := &curdmodel.QueryRequest{}
+	resp := &curdmodel.QueryResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "请求参数无效:PageName为空")
+		return
+	}
+	err := c.BindQuery(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.Trash(req, resp)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Restore godoc
+// @Summary 恢复
+// @Description 恢复回收站中的记录
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body curdmodel.BatchRequest true "Restore"
+// @Success 200 {object} curdmodel.BatchResponse
+// @Router /api/curd/common/{pageName}/restore [post]
+func Restore(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.BatchRequest{}
+	resp := &curdmodel.BatchResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.Restore(req, resp)
+	if len(resp.Failures) > 0 {
+		log.Warnf(context.Background(), "TransID:%s,恢复失败:%s", transID, resp.Message)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Purge godoc
+// @Summary 彻底删除
+// @Description 彻底删除回收站中的记录
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param data body curdmodel.BatchRequest true "Purge"
+// @Success 200 {object} curdmodel.BatchResponse
+// @Router /api/curd/common/{pageName}/purge [delete]
+func Purge(c *gin.Context) {
+	transID := middleware.GetTransID(c)
+	req := &curdmodel.BatchRequest{}
+	resp := &curdmodel.BatchResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:PageName为空", transID)
+		return
+	}
+	err := c.BindJSON(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "TransID:%s,请求参数无效:%v", transID, err)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.Purge(req, resp)
+	if len(resp.Failures) > 0 {
+		log.Warnf(context.Background(), "TransID:%s,彻底删除失败:%s", transID, resp.Message)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// History godoc
+// @Summary 变更历史
+// @Description 分页查询记录的变更历史,页面需要开启变更历史
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param id query string true "记录ID"
+// @Param pageIndex query int false "从1开始"
+// @Param pageSize query int false "默认每页10条"
+// @Success 200 {object} curdmodel.HistoryResponse
+// @Router /api/curd/common/{pageName}/history [get]
+func History(c *gin.Context) {
+	req := &curdmodel.HistoryRequest{}
+	resp := &curdmodel.HistoryResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "请求参数无效:PageName为空")
+		return
+	}
+	err := c.BindQuery(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.History(req, resp)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// WebhookDeliveries godoc
+// @Summary webhook推送记录
+// @Description 分页查询页面的webhook推送记录,失败的推送会按照指数退避重试,超过最多次数后状态为failed
+// @Tags 通用增删改查接口
+// @Accept  json
+// @Produce  json
+// @Param pageName path string true "页面配置名称"
+// @Param authorization header string true "jwt token"
+// @Param recordID query string false "记录ID"
+// @Param eventID query string false "事件ID"
+// @Param status query string false "pending,success,failed"
+// @Param pageIndex query int false "从1开始"
+// @Param pageSize query int false "默认每页10条"
+// @Success 200 {object} curdmodel.WebhookDeliveryResponse
+// @Router /api/curd/common/{pageName}/webhook/deliveries [get]
+func WebhookDeliveries(c *gin.Context) {
+	req := &curdmodel.WebhookDeliveryRequest{}
+	resp := &curdmodel.WebhookDeliveryResponse{
+		CommonResponse: model.CommonResponse{
+			Code: model.Success,
+		},
+	}
+	pageName := c.Param("pageName")
+	if pageName == "" {
+		resp.Code = model.BadRequest
+		c.JSON(http.StatusOK, resp)
+		log.Warnf(context.Background(), "请求参数无效:PageName为空")
+		return
+	}
+	err := c.BindQuery(req)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	req.PageName = pageName
+	req.Operator = getOperator(c)
+	curdmodel.WebhookDeliveries(req, resp)
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func getOperator(c *gin.Context) *curdmodel.Operator {
+	op := &curdmodel.Operator{
+		TransID: middleware.GetTransID(c),
+	}
+	if exists, user := middleware.GetUser(c); exists && user != nil {
+		op.UserID = user.Id
+		op.UserName = user.UserName
+		op.TenantID = user.TenantID
+		op.RoleIDs = user.RoleIDs
+		op.Group = user.Group
+	}
+	// 只有平台租户可以通过allTenants访问所有租户的数据
+	op.AllTenants = op.TenantID == constants.PlatformTenantID && c.Query("allTenants") == "true"
+	return op
+}
+
 // :pageName是否为了控制接口的权限
 func RegisterCurdRouter(r *gin.Engine) {
 	g := r.Group("/api/curd/common")
 
 	g.POST("/:pageName/add", Add)
 	g.PUT("/:pageName/update", Update)
+	g.PATCH("/:pageName/patch", Patch)
 	g.GET("/:pageName/query", Query)
+	g.GET("/:pageName/aggregate", Aggregate)
 	g.GET("/:pageName/tree", GetTree)
+	g.GET("/:pageName/tree/children", TreeChildren)
+	g.GET("/:pageName/tree/path", TreePath)
+	g.POST("/:pageName/tree/move", TreeMove)
 	g.DELETE("/:pageName/delete", Delete)
 	g.GET("/:pageName/all", GetAll)
 	g.GET("/:pageName/detail", GetDetail)
 	g.GET("/:pageName/detail/name", GetDetailByName)
 	g.POST("/:pageName/copy", Copy)
 	g.POST("/:pageName/enable", Enable)
+	g.DELETE("/:pageName/batch/delete", BatchDelete)
+	g.POST("/:pageName/batch/enable", BatchEnable)
+	g.PUT("/:pageName/batch/update", BatchUpdate)
+	g.GET("/:pageName/export", Export)
+	g.POST("/:pageName/import", Import)
+	g.GET("/:pageName/trash", Trash)
+	g.POST("/:pageName/restore", Restore)
+	g.DELETE("/:pageName/purge", Purge)
+	g.GET("/:pageName/history", History)
+	g.GET("/:pageName/webhook/deliveries", WebhookDeliveries)
 }
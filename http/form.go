@@ -265,7 +265,7 @@ func GetFormDetail(c *gin.Context) {
 
 // UpdateFormSchema godoc
 // @Summary 更新表单Schema
-// @Description 更新表单Schema
+// @Description 更新表单Schema,传了updatedAt时会检查表单是否已经被其他人修改,已修改返回40003
 // @Tags 表单管理
 // @Accept  json
 // @Produce  json
@@ -289,7 +289,7 @@ func UpdateFormSchema(c *gin.Context) {
 	}
 	err = form.UpdateFormSchema(form.PBToForm(req))
 	if err != nil {
-		resp.Code = model.InternalServerError
+		resp.Code = int(form.ErrorCode(err, apipb.Code_InternalServerError))
 		resp.Message = err.Error()
 	}
 	c.JSON(http.StatusOK, resp)
@@ -63,7 +63,7 @@ func AddPage(c *gin.Context) {
 
 // UpdatePage godoc
 // @Summary 更新页面配置
-// @Description 更新页面配置
+// @Description 更新页面配置,传了updatedAt时会检查页面配置是否已经被其他人修改,已修改返回40003
 // @Tags 页面配置
 // @Accept  json
 // @Produce  json
@@ -99,7 +99,7 @@ func UpdatePage(c *gin.Context) {
 	}
 	err = curdmodel.UpdatePage(curdmodel.PBToPage(req))
 	if err != nil {
-		resp.Code = apipb.Code_InternalServerError
+		resp.Code = curdmodel.ErrorCode(err, apipb.Code_InternalServerError)
 		resp.Message = err.Error()
 	}
 	c.JSON(http.StatusOK, resp)
@@ -263,7 +263,7 @@ func GetAllPage(c *gin.Context) {
 
 // GetPageDetail godoc
 // @Summary 查询明细
-// @Description 查询明细
+// @Description 查询明细,fieldAccess返回当前用户每个字段生效的权限:hidden、readonly或者readwrite,webhook已经设置的secret返回******
 // @Tags 页面配置
 // @Accept  json
 // @Produce  json
@@ -289,13 +289,44 @@ func GetPageDetail(c *gin.Context) {
 		resp.Message = err.Error()
 	} else {
 		resp.Data = curdmodel.PageToPB(page)
+		resp.Data.FieldAccess = curdmodel.FieldAccess(getOperator(c), page)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetPageDesign godoc
+// @Summary 页面设计器查询明细
+// @Description 页面设计器查询完整的页面配置,包括webhook的secret;修改页面配置时secret传回******表示不修改
+// @Tags 页面配置
+// @Accept  json
+// @Produce  json
+// @Param id query string true "ID"
+// @Param authorization header string true "jwt token"
+// @Success 200 {object} apipb.GetPageDetailResponse
+// @Router /api/curd/page/design [get]
+func GetPageDesign(c *gin.Context) {
+	resp := &apipb.GetPageDetailResponse{
+		Code: apipb.Code_Success,
+	}
+	idStr := c.Query("id")
+	if idStr == "" {
+		resp.Code = apipb.Code_BadRequest
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	page, err := curdmodel.GetPageByID(idStr)
+	if err != nil {
+		resp.Code = apipb.Code_InternalServerError
+		resp.Message = err.Error()
+	} else {
+		resp.Data = curdmodel.PageToDesignPB(page)
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
 // GetPageDetailByName godoc
 // @Summary 根据名称查询明细
-// @Description 根据名称查询明细
+// @Description 根据名称查询明细,fieldAccess返回当前用户每个字段生效的权限:hidden、readonly或者readwrite,webhook已经设置的secret返回******
 // @Tags 页面配置
 // @Accept  json
 // @Produce  json
@@ -320,6 +351,7 @@ func GetPageDetailByName(c *gin.Context) {
 		resp.Message = err.Error()
 	} else {
 		resp.Data = curdmodel.PageToPB(page)
+		resp.Data.FieldAccess = curdmodel.FieldAccess(getOperator(c), page)
 	}
 	c.JSON(http.StatusOK, resp)
 }
@@ -453,6 +485,8 @@ func ImportPage(c *gin.Context) {
 	successCount := 0
 	failCount := 0
 	for _, page := range pages {
+		//导入时直接覆盖,不检查页面配置是否被修改
+		page.UpdatedAt = 0
 		err = curdmodel.UpdatePage(curdmodel.PBToPage(page))
 		if err == gorm.ErrRecordNotFound {
 			err = curdmodel.CreatePage(curdmodel.PBToPage(page))
@@ -478,6 +512,7 @@ func RegisterPageRouter(r *gin.Engine) {
 	g.GET("all", GetAllPage)
 	g.GET("detail", GetPageDetail)
 	g.GET("detail/name", GetPageDetailByName)
+	g.GET("design", GetPageDesign)
 	g.POST("copy", CopyPage)
 	g.POST("enable", EnablePage)
 	g.GET("export", ExportPage)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
@@ -44,6 +45,9 @@ func main() {
 	constants.SetPlatformTenantID(curdconfig.DefaultConfig.PlatformTenantID)
 
 	model.Init(curdconfig.DefaultConfig.Mysql, curdconfig.DefaultConfig.Debug)
+	if !curdconfig.DefaultConfig.Webhook.Disable {
+		go model.NewWebhookDispatcher().Run(context.Background())
+	}
 	fmt.Println("started server")
 	gen.LoadCache()
 	Start(48081)
@@ -200,15 +200,12 @@ func QueryCell(req *apipb.QueryCellRequest, resp *apipb.QueryCellResponse, prelo
 	}
 
 	// orderStr := "`system`,`index`"
-	orderStr := "`updated_at` desc"
-	if req.OrderField != "" {
-		if req.Desc {
-			orderStr = req.OrderField + " desc"
-		} else {
-			orderStr = req.OrderField
-		}
+	orderStr, err := OrderByModel(&Cell{}, req.OrderField, req.Desc, orderClause("updated_at", true))
+	if err != nil {
+		resp.Code = apipb.Code_BadRequest
+		resp.Message = err.Error()
+		return
 	}
-	var err error
 	var list []*Cell
 
 	resp.Records, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.PageIndex, orderStr, &list, nil)
@@ -324,7 +321,7 @@ func GetAllCells(req *apipb.GetAllCellRequest) (list []*Cell, err error) {
 	if req.ProjectID != "" {
 		db = db.Where("project_id = ?", req.ProjectID)
 	}
-	err = db.Preload("Markup").Preload("Attrs").Preload("Connectings").Preload(clause.Associations).Order("`index`").Find(&list).Error
+	err = db.Preload("Markup").Preload("Attrs").Preload("Connectings").Preload(clause.Associations).Order(QuoteColumn("index")).Find(&list).Error
 	return
 }
 
@@ -9,7 +9,8 @@ func PBToPage(in *apipb.PageInfo) *Page {
 	return &Page{
 		TenantModel: commonmodel.TenantModel{
 			Model: commonmodel.Model{
-				ID: in.Id,
+				ID:        in.Id,
+				UpdatedAt: UnixTime(in.UpdatedAt),
 			},
 			TenantID: in.TenantID,
 		},
@@ -105,6 +106,13 @@ func PBToPage(in *apipb.PageInfo) *Page {
 		IsChild:  in.IsChild,
 		Children: in.Pages,
 		Bordered: in.Bordered,
+
+		EnableAudit: in.EnableAudit,
+		Hooks:       PageHooksToPB(in.Hooks),
+		Webhooks:    PageWebhooksToPB(in.Webhooks),
+		DataRules:   PageDataRulesToPB(in.DataRules),
+
+		FieldPermissions: PageFieldPermissionsToPB(in.FieldPermissions),
 	}
 }
 
@@ -156,6 +164,139 @@ func PBToPageButtons(btns []*PageButton) []*apipb.PageButton {
 	return list
 }
 
+func PageHooksToPB(hooks []*apipb.PageHook) []*PageHook {
+	var list []*PageHook
+	for _, hook := range hooks {
+		list = append(list, &PageHook{
+			ID:         hook.Id,
+			PageID:     hook.PageID,
+			Event:      hook.Event,
+			Type:       hook.Type,
+			Field:      hook.Field,
+			Expression: hook.Expression,
+			Message:    hook.Message,
+			Index:      hook.Index,
+			Enable:     hook.Enable,
+		})
+	}
+	return list
+}
+
+func PBToPageHooks(hooks []*PageHook) []*apipb.PageHook {
+	var list []*apipb.PageHook
+	for _, hook := range hooks {
+		list = append(list, &apipb.PageHook{
+			Id:         hook.ID,
+			PageID:     hook.PageID,
+			Event:      hook.Event,
+			Type:       hook.Type,
+			Field:      hook.Field,
+			Expression: hook.Expression,
+			Message:    hook.Message,
+			Index:      hook.Index,
+			Enable:     hook.Enable,
+		})
+	}
+	return list
+}
+
+func PageWebhooksToPB(webhooks []*apipb.PageWebhook) []*PageWebhook {
+	var list []*PageWebhook
+	for _, webhook := range webhooks {
+		list = append(list, &PageWebhook{
+			ID:     webhook.Id,
+			PageID: webhook.PageID,
+			Name:   webhook.Name,
+			URL:    webhook.Url,
+			Secret: webhook.Secret,
+			Events: webhook.Events,
+			Enable: webhook.Enable,
+		})
+	}
+	return list
+}
+
+// PBToPageWebhooks 已经设置的secret返回WebhookSecretMask,只有页面设计器使用PageToDesignPB返回secret
+func PBToPageWebhooks(webhooks []*PageWebhook) []*apipb.PageWebhook {
+	var list []*apipb.PageWebhook
+	for _, webhook := range webhooks {
+		secret := ""
+		if webhook.Secret != "" {
+			secret = WebhookSecretMask
+		}
+		list = append(list, &apipb.PageWebhook{
+			Id:     webhook.ID,
+			PageID: webhook.PageID,
+			Name:   webhook.Name,
+			Url:    webhook.URL,
+			Secret: secret,
+			Events: webhook.Events,
+			Enable: webhook.Enable,
+		})
+	}
+	return list
+}
+
+func PageDataRulesToPB(rules []*apipb.PageDataRule) []*PageDataRule {
+	var list []*PageDataRule
+	for _, rule := range rules {
+		list = append(list, &PageDataRule{
+			ID:          rule.Id,
+			PageID:      rule.PageID,
+			RoleID:      rule.RoleID,
+			Rule:        rule.Rule,
+			Description: rule.Description,
+			Index:       rule.Index,
+			Enable:      rule.Enable,
+		})
+	}
+	return list
+}
+
+func PBToPageDataRules(rules []*PageDataRule) []*apipb.PageDataRule {
+	var list []*apipb.PageDataRule
+	for _, rule := range rules {
+		list = append(list, &apipb.PageDataRule{
+			Id:          rule.ID,
+			PageID:      rule.PageID,
+			RoleID:      rule.RoleID,
+			Rule:        rule.Rule,
+			Description: rule.Description,
+			Index:       rule.Index,
+			Enable:      rule.Enable,
+		})
+	}
+	return list
+}
+
+func PageFieldPermissionsToPB(permissions []*apipb.PageFieldPermission) []*PageFieldPermission {
+	var list []*PageFieldPermission
+	for _, permission := range permissions {
+		list = append(list, &PageFieldPermission{
+			ID:         permission.Id,
+			PageID:     permission.PageID,
+			RoleID:     permission.RoleID,
+			Field:      permission.Field,
+			Permission: permission.Permission,
+		})
+	}
+	return list
+}
+
+func PBToPageFieldPermissions(permissions []*PageFieldPermission) []*apipb.PageFieldPermission {
+	var list []*apipb.PageFieldPermission
+	for _, permission := range permissions {
+		list = append(list, &apipb.PageFieldPermission{
+			Id:         permission.ID,
+			PageID:     permission.PageID,
+			RoleID:     permission.RoleID,
+			Field:      permission.Field,
+			Permission: permission.Permission,
+		})
+	}
+	return list
+}
+
 func PageToPB(in *Page) *apipb.PageInfo {
 	return &apipb.PageInfo{
 		TenantID:             in.TenantID,
@@ -254,7 +395,23 @@ func PageToPB(in *Page) *apipb.PageInfo {
 		IsChild:  in.IsChild,
 		Pages:    in.Children,
 		Bordered: in.Bordered,
+
+		EnableAudit: in.EnableAudit,
+		Hooks:       PBToPageHooks(in.Hooks),
+		Webhooks:    PBToPageWebhooks(in.Webhooks),
+		DataRules:   PBToPageDataRules(in.DataRules),
+
+		FieldPermissions: PBToPageFieldPermissions(in.FieldPermissions),
+	}
+}
+
+// PageToDesignPB 页面设计器读取完整的页面配置,包括webhook的secret
+func PageToDesignPB(in *Page) *apipb.PageInfo {
+	out := PageToPB(in)
+	for i, webhook := range in.Webhooks {
+		out.Webhooks[i].Secret = webhook.Secret
 	}
+	return out
 }
 
 func PagesToPB(in []*Page) []*apipb.PageInfo {
@@ -391,6 +548,7 @@ func PBToMetadata(in *apipb.MetadataInfo) *Metadata {
 		ProjectID:      in.ProjectID,
 		MetadataFields: PBToMetadataFields(in.MetadataFields),
 		IsMust:         in.IsMust,
+		IDStrategy:     in.IdStrategy,
 	}
 }
 
@@ -408,6 +566,7 @@ func MetadataToPB(in *Metadata) *apipb.MetadataInfo {
 		MetadataFields: MetadataFieldsToPB(in.MetadataFields),
 		Children:       MetadatasToPB(in.Children),
 		IsMust:         in.IsMust,
+		IdStrategy:     in.IDStrategy,
 	}
 }
 
@@ -448,6 +607,12 @@ func PBToMetadataFields(in []*apipb.MetadataField) []*MetadataField {
 			DotNotGen:    field.DotNotGen,
 			PBToStruct:   field.PbToStruct,
 			StructToPB:   field.StructToPB,
+
+			Encrypt:         field.Encrypt,
+			BlindIndexField: field.BlindIndexField,
+			Mask:            field.Mask,
+			UnmaskRoles:     field.UnmaskRoles,
+			CodeRule:        field.CodeRule,
 		})
 	}
 	return list
@@ -480,6 +645,12 @@ func MetadataFieldsToPB(in []*MetadataField) []*apipb.MetadataField {
 			DotNotGen:    field.DotNotGen,
 			PbToStruct:   field.PBToStruct,
 			StructToPB:   field.StructToPB,
+
+			Encrypt:         field.Encrypt,
+			BlindIndexField: field.BlindIndexField,
+			Mask:            field.Mask,
+			UnmaskRoles:     field.UnmaskRoles,
+			CodeRule:        field.CodeRule,
 		})
 	}
 	return list
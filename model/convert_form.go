@@ -10,7 +10,8 @@ func PBToForm(in *apipb.FormInfo) *Form {
 	return &Form{
 		TenantModel: commonmodel.TenantModel{
 			Model: commonmodel.Model{
-				ID: in.Id,
+				ID:        in.Id,
+				UpdatedAt: UnixTime(in.UpdatedAt),
 			},
 			TenantID: in.TenantID,
 		},
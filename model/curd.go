@@ -1,40 +1,69 @@
 package model
 
 import (
+	gosql "database/sql"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/CloudSilk/pkg/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 )
 
 var NamingStrategy schema.NamingStrategy
 
-func Create(pageName string, m map[string]interface{}) error {
-
+func Create(op *Operator, pageName string, m map[string]interface{}) error {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return err
 	}
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		return createRecord(tx, op, page, m)
+	})
+}
+
+func createRecord(tx *gorm.DB, op *Operator, page *Page, m map[string]interface{}) error {
 	md := page.Metadata
-	data := make(map[string]interface{})
-	for _, field := range md.MetadataFields {
-		data[LowerSnakeCase(field.Name)] = m[field.Name]
+	// 没有写权限的字段忽略传入的值,使用默认值
+	for name := range fieldPermissions(op, page) {
+		delete(m, name)
+	}
+	if err := ApplyDefaultValues(op, page, m); err != nil {
+		return err
+	}
+	applyTenant(op, md, m)
+	initRecord(md, m)
+	if err := generateCodes(tx, md, m); err != nil {
+		return err
+	}
+	if err := runRecordHooks(tx, op, page, HookBeforeCreate, nil, m, nil); err != nil {
+		return err
+	}
+	if err := ValidateRecord(tx, op, md, m, false); err != nil {
+		return err
+	}
+	if err := setBlindIndexes(md, m); err != nil {
+		return err
 	}
-
 	var uniqueFields []string
 	var fieldValues []interface{}
 
 	for _, field := range md.MetadataFields {
 		if field.Unique {
-			uniqueFields = append(uniqueFields, " "+LowerSnakeCase(field.Name)+" =? ")
+			field, err := uniqueField(md, field)
+			if err != nil {
+				return err
+			}
+			uniqueFields = append(uniqueFields, " "+QuoteColumn(LowerSnakeCase(field.Name))+" =? ")
 			fieldValues = append(fieldValues, m[field.Name])
 		}
 	}
 
-	if len(uniqueFields) > 1 {
-		duplication, err := dbClient.CheckDuplication(dbClient.DB().Table(NamingStrategy.TableName(page.Metadata.Name)), strings.Join(uniqueFields, " and "), fieldValues...)
+	if len(uniqueFields) > 0 {
+		duplication, err := dbClient.CheckDuplication(uniqueDB(tx, op, page), strings.Join(uniqueFields, " and "), fieldValues...)
 		if err != nil {
 			return err
 		}
@@ -43,68 +72,205 @@ func Create(pageName string, m map[string]interface{}) error {
 		}
 	}
 
-	var updateFields []string
-	var updateValues []interface{}
-	var list []string
+	idName := "id"
+	if field := md.FieldByName("id"); field != nil {
+		idName = field.Name
+	}
+	// 不使用数据库自增时由系统生成ID,传入了ID时使用传入的值
+	var columns []string
+	row := make(map[string]interface{})
+	newID := newRecordID(md)
+	if newID != nil {
+		if !isEmptyValue(m[idName]) {
+			newID = m[idName]
+		}
+		columns = append(columns, "id")
+		row["id"] = newID
+	}
 	for _, field := range md.MetadataFields {
-		if field.Name == "id" || field.Name == "ID" {
+		if field.Name == "id" || field.Name == "ID" || IsRelationField(md, field) {
 			continue
 		}
-		updateFields = append(updateFields, LowerSnakeCase(field.Name))
-		list = append(list, "?")
-		updateValues = append(updateValues, m[field.Name])
+		column := LowerSnakeCase(field.Name)
+		columns = append(columns, column)
+		row[column] = m[field.Name]
+	}
+	// 开启租户模式但是元数据中没有定义TenantID字段
+	if tenantID, ok := m[TenantColumn]; ok && md.FieldByName(TenantColumn) == nil {
+		columns = append(columns, TenantColumn)
+		row[TenantColumn] = tenantID
+	}
+	if err := encryptColumns(md, row); err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = row[column]
 	}
 
-	insertSql := fmt.Sprintf("insert into `%s`(%s) values(%s)", NamingStrategy.TableName(page.Metadata.Name), strings.Join(updateFields, ","), strings.Join(list, ","))
-	return dbClient.DB().Exec(insertSql, updateValues...).Error
+	id, err := execInsert(tx, NamingStrategy.TableName(page.Metadata.Name), columns, values)
+	if err != nil {
+		return err
+	}
+	if newID != nil {
+		id = newID
+	}
+	if id != nil {
+		m[idName] = id
+	}
+	if err = checkDataPermission(tx, op, page, id); err != nil {
+		return err
+	}
+	if err = saveChildren(tx, op, md, id, m); err != nil {
+		return err
+	}
+	if err = runRecordHooks(tx, op, page, HookAfterCreate, id, m, nil); err != nil {
+		return err
+	}
+	if err = writeAudit(tx, op, page, AuditActionCreate, id, nil); err != nil {
+		return err
+	}
+	return writeOutbox(tx, op, page, AuditActionCreate, id, nil)
 }
 
-func Delete(pageName string, id string) (err error) {
+// insertStatement 使用gorm的clause生成插入语句,表名和字段名按照数据库方言加引号,
+// Postgres不支持LastInsertId,使用RETURNING返回自增ID
+func insertStatement(tx *gorm.DB, table string, columns []string, values []interface{}) (*gorm.Statement, bool) {
+	stmt := &gorm.Statement{
+		DB:       tx,
+		ConnPool: tx.Statement.ConnPool,
+		Context:  tx.Statement.Context,
+		Clauses:  map[string]clause.Clause{},
+	}
+	insertColumns := make([]clause.Column, len(columns))
+	for i, column := range columns {
+		insertColumns[i] = clause.Column{Name: column}
+	}
+	stmt.AddClause(clause.Insert{Table: clause.Table{Name: table}})
+	stmt.AddClause(clause.Values{Columns: insertColumns, Values: [][]interface{}{values}})
+	returning := tx.Dialector.Name() == "postgres"
+	if returning {
+		stmt.AddClause(clause.Returning{Columns: []clause.Column{{Name: "id"}}})
+		stmt.Build("INSERT", "VALUES", "RETURNING")
+	} else {
+		stmt.Build("INSERT", "VALUES")
+	}
+	return stmt, returning
+}
+
+// execInsert 执行插入语句并返回自增ID,数据库驱动不支持返回自增ID时返回nil
+func execInsert(tx *gorm.DB, table string, columns []string, values []interface{}) (id interface{}, err error) {
+	stmt, returning := insertStatement(tx, table, columns, values)
+	sql := stmt.SQL.String()
+	begin := time.Now()
+	var rows int64
+	if returning {
+		err = stmt.ConnPool.QueryRowContext(stmt.Context, sql, stmt.Vars...).Scan(&id)
+		if err == nil {
+			rows = 1
+		}
+	} else {
+		var result gosql.Result
+		result, err = stmt.ConnPool.ExecContext(stmt.Context, sql, stmt.Vars...)
+		if err == nil {
+			rows, _ = result.RowsAffected()
+			if lastID, e := result.LastInsertId(); e == nil {
+				id = lastID
+			}
+		}
+	}
+	tx.Logger.Trace(stmt.Context, begin, func() (string, int64) {
+		return tx.Dialector.Explain(sql, stmt.Vars...), rows
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func Delete(op *Operator, pageName string, id string) (err error) {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return err
 	}
-	return dbClient.DB().Exec(fmt.Sprintf("delete from %s where id=?", NamingStrategy.TableName(page.Metadata.Name)), id).Error
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		return deleteByID(tx, op, page, id)
+	})
 }
 
 type QueryResponse struct {
 	model.CommonResponse
 	Data []map[string]interface{} `json:"data"`
+	// NextCursor 游标分页时下一页的位置,没有下一页时为空
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 type QueryRequest struct {
 	model.CommonRequest
 	PageName string                 `json:"pageName" form:"pageName" uri:"pageName"`
 	Data     map[string]interface{} `json:"data" form:"data" uri:"data"`
+	// Keyword 在所有开启了Like的字段中模糊查询
+	Keyword string `json:"keyword" form:"keyword" uri:"keyword"`
+	// Expand 需要展开的引用字段,多个使用逗号隔开,使用.展开下一层
+	Expand string `json:"expand" form:"expand" uri:"expand"`
+	// ExpandMode 展开方式,object返回完整记录,label只返回LabelField
+	ExpandMode string `json:"expandMode" form:"expandMode" uri:"expandMode"`
+	// UseCursor 使用游标分页,按照排序字段和id定位下一页,不使用OFFSET
+	UseCursor bool `json:"useCursor" form:"useCursor" uri:"useCursor"`
+	// Cursor 上一页返回的nextCursor,传了Cursor时自动使用游标分页
+	Cursor string `json:"cursor" form:"cursor" uri:"cursor"`
+	// SkipCount 不查询总记录数
+	SkipCount bool      `json:"skipCount" form:"skipCount" uri:"skipCount"`
+	Operator  *Operator `json:"-" form:"-" uri:"-"`
 }
 
 func Query(req *QueryRequest, resp *QueryResponse) {
+	query(req, resp, false)
+}
+
+// query trash为true时查询回收站中的记录
+func query(req *QueryRequest, resp *QueryResponse, trash bool) {
 	page, err := GetPageByName(req.PageName)
 	if err != nil {
 		resp.Code = model.InternalServerError
 		resp.Message = err.Error()
 		return
 	}
-
-	db := dbClient.DB().Table(NamingStrategy.TableName(page.Metadata.Name))
-
-	for key, value := range req.Data {
-		db = db.Where(fmt.Sprintf("%s = ?", key), value)
+	if trash && !IsSoftDeleteMetadata(page.Metadata) {
+		resp.Code = model.BadRequest
+		resp.Message = ErrSoftDeleteNotSupported.Error()
+		return
 	}
 
-	OrderStr := "`id`"
-	if req.OrderField != "" {
-		if req.Desc {
-			OrderStr = req.OrderField + " desc"
-		} else {
-			OrderStr = req.OrderField
-		}
+	permissions := fieldPermissions(req.Operator, page)
+	md := readableMetadata(page.Metadata, permissions)
+	filters, err := ParseFilters(req.Operator, md, req.Data)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
 	}
 
-	resp.Total, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.Current, OrderStr, &resp.Data, nil)
+	db := recordDB(dbClient.DB(), req.Operator, page)
+	defaultColumn, defaultDesc := "id", false
+	if trash {
+		db = trashDB(dbClient.DB(), req.Operator, page)
+		defaultColumn, defaultDesc = DeletedAtColumn, true
+	}
+	db, err = ApplyKeyword(ApplyFilters(db, filters), req.Operator, md, req.Keyword)
 	if err != nil {
-		resp.Code = model.InternalServerError
+		resp.Code = model.BadRequest
 		resp.Message = err.Error()
+		return
+	}
+
+	if req.UseCursor || req.Cursor != "" {
+		err = cursorQuery(db, md, req, resp, defaultColumn, defaultDesc)
+	} else {
+		err = offsetQuery(db, md, req, resp, orderClause(defaultColumn, defaultDesc))
+	}
+	if err != nil {
+		return
 	}
 	result := make([]map[string]interface{}, len(resp.Data))
 	for i, data := range resp.Data {
@@ -115,15 +281,91 @@ func Query(req *QueryRequest, resp *QueryResponse) {
 		result[i] = d
 	}
 	resp.Data = result
+	stripHiddenFields(permissions, resp.Data...)
+	if err = revealRecords(req.Operator, page.Metadata, resp.Data...); err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	if err = ExpandRecords(req.Operator, page, resp.Data, req.Expand, req.ExpandMode); err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+	}
+}
+
+// offsetQuery 使用OFFSET分页,SkipCount为true时不查询总记录数
+func offsetQuery(db *gorm.DB, md *Metadata, req *QueryRequest, resp *QueryResponse, defaultOrder string) error {
+	orderStr, err := OrderByMetadata(req.Operator, md, req.OrderField, req.Desc, defaultOrder)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return err
+	}
+	if !req.SkipCount {
+		resp.Total, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.Current, orderStr, &resp.Data, nil)
+	} else {
+		pageSize, current := pageSizeAndCurrent(req)
+		err = db.Order(orderStr).Offset(int(pageSize * (current - 1))).Limit(int(pageSize)).Find(&resp.Data).Error
+	}
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+	return err
 }
 
-func GetAll(pageName string) (list []map[string]interface{}, err error) {
+// cursorQuery 使用游标分页,多查询一条记录用来判断是否还有下一页
+func cursorQuery(db *gorm.DB, md *Metadata, req *QueryRequest, resp *QueryResponse, defaultColumn string, defaultDesc bool) error {
+	p, err := newCursorPage(req.Operator, md, req.OrderField, req.Desc, defaultColumn, defaultDesc)
+	var pageDB *gorm.DB
+	if err == nil {
+		pageDB, err = p.where(db.Session(&gorm.Session{}), req.Cursor)
+	}
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return err
+	}
+	// Total是所有符合条件的记录数,不包含游标的条件,每一页都一样
+	if !req.SkipCount {
+		if err = db.Session(&gorm.Session{}).Count(&resp.Total).Error; err != nil {
+			resp.Code = model.InternalServerError
+			resp.Message = err.Error()
+			return err
+		}
+	}
+	pageSize, _ := pageSizeAndCurrent(req)
+	var rows []map[string]interface{}
+	err = pageDB.Order(p.order()).Limit(int(pageSize) + 1).Find(&rows).Error
+	if err == nil {
+		resp.Data, resp.NextCursor, err = p.next(rows, int(pageSize))
+	}
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+	return err
+}
+
+// pageSizeAndCurrent 和dbClient.PageQuery一样,默认每页10条,从第1页开始
+func pageSizeAndCurrent(req *QueryRequest) (int64, int64) {
+	pageSize, current := req.PageSize, req.Current
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if current <= 0 {
+		current = 1
+	}
+	return pageSize, current
+}
+
+func GetAll(op *Operator, pageName string) (list []map[string]interface{}, err error) {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return nil, err
 	}
 	var result []map[string]interface{}
-	err = dbClient.DB().Table(NamingStrategy.TableName(page.Metadata.Name)).Find(&result).Error
+	err = recordDB(dbClient.DB(), op, page).Find(&result).Error
 	if err != nil {
 		return nil, err
 	}
@@ -134,152 +376,228 @@ func GetAll(pageName string) (list []map[string]interface{}, err error) {
 		}
 		list = append(list, d)
 	}
+	stripHiddenFields(fieldPermissions(op, page), list...)
+	err = revealRecords(op, page.Metadata, list...)
 	return
 }
 
-func GetDetailById(pageName string, id string) (data map[string]interface{}, err error) {
+// GetDetailById 根据ID获取记录,expand和expandMode参考QueryRequest
+func GetDetailById(op *Operator, pageName string, id string, expand, expandMode string) (data map[string]interface{}, err error) {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return nil, err
 	}
 	data = make(map[string]interface{})
 	result := make(map[string]interface{})
-	err = dbClient.DB().Raw(fmt.Sprintf("select * from %s where id=?", NamingStrategy.TableName(page.Metadata.Name)), id).Scan(&result).Error
+	err = recordDB(dbClient.DB(), op, page).Where("id = ?", id).Limit(1).Find(&result).Error
 	if err != nil {
 		return nil, err
 	}
 	for key, value := range result {
 		data[CamelName2(key)] = value
 	}
+	stripHiddenFields(fieldPermissions(op, page), data)
+	if err = revealRecords(op, page.Metadata, data); err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		err = ExpandRecords(op, page, []map[string]interface{}{data}, expand, expandMode)
+	}
 	return
 }
 
-func Update(pageName string, m map[string]interface{}) error {
+func Update(op *Operator, pageName string, m map[string]interface{}) error {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return err
 	}
-	var uniqueFields []string
-	var fieldValues []interface{}
 	md := page.Metadata
-	uniqueFields = append(uniqueFields, "id <> ?")
-	fieldValues = append(fieldValues, m["ID"])
-	for _, field := range md.MetadataFields {
-		if field.Unique {
-			uniqueFields = append(uniqueFields, " "+LowerSnakeCase(field.Name)+" =? ")
-			fieldValues = append(fieldValues, m[field.Name])
-		}
-	}
-
-	var updateFields []string
-	var updateValues []interface{}
-	for _, field := range md.MetadataFields {
-		updateFields = append(updateFields, LowerSnakeCase(field.Name)+"=?")
-		updateValues = append(updateValues, m[field.Name])
-	}
 	id := m["id"]
 	if id == nil {
 		id = m["ID"]
 	}
-	updateValues = append(updateValues, id)
-	if len(uniqueFields) > 1 {
-		duplication, err := dbClient.CheckDuplication(dbClient.DB().Table(NamingStrategy.TableName(page.Metadata.Name)), strings.Join(uniqueFields, " and "), fieldValues...)
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		before, err := lockRecord(tx, op, page, id)
 		if err != nil {
 			return err
 		}
-		if duplication {
-			return errors.New("存在相同" + page.Title)
+		if err = checkRecordVersion(md, before, m); err != nil {
+			return err
+		}
+		// 没有写权限的字段忽略传入的值,保持原来的值
+		for name := range fieldPermissions(op, page) {
+			field := md.FieldByName(name)
+			value, err := plainValue(field, derefValue(before[LowerSnakeCase(name)]))
+			if err != nil {
+				return err
+			}
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			m[name] = value
+		}
+		if err = keepMaskedValues(op, md, m, before); err != nil {
+			return err
+		}
+		if err = runRecordHooks(tx, op, page, HookBeforeUpdate, id, m, before); err != nil {
+			return err
+		}
+		if err = ValidateRecord(tx, op, md, m, false); err != nil {
+			return err
+		}
+		if err = setBlindIndexes(md, m); err != nil {
+			return err
+		}
+		var uniqueFields []string
+		var fieldValues []interface{}
+		uniqueFields = append(uniqueFields, "id <> ?")
+		fieldValues = append(fieldValues, id)
+		for _, field := range md.MetadataFields {
+			if field.Unique {
+				if field, err = uniqueField(md, field); err != nil {
+					return err
+				}
+				uniqueFields = append(uniqueFields, " "+QuoteColumn(LowerSnakeCase(field.Name))+" =? ")
+				fieldValues = append(fieldValues, m[field.Name])
+			}
+		}
+		if len(uniqueFields) > 1 {
+			duplication, err := dbClient.CheckDuplication(uniqueDB(tx, op, page), strings.Join(uniqueFields, " and "), fieldValues...)
+			if err != nil {
+				return err
+			}
+			if duplication {
+				return errors.New("存在相同" + page.Title)
+			}
+		}
+
+		updateValues := make(map[string]interface{})
+		for _, field := range md.MetadataFields {
+			column := LowerSnakeCase(field.Name)
+			// 租户ID在新增的时候确定,删除时间只能通过删除和恢复修改
+			if column == "id" || column == DeletedAtColumn || column == CreatedAtColumn || isLockColumn(column) || (column == TenantColumn && IsTenantMetadata(md)) {
+				continue
+			}
+			if IsRelationField(md, field) {
+				continue
+			}
+			updateValues[column] = m[field.Name]
+		}
+		if err = encryptColumns(md, updateValues); err != nil {
+			return err
+		}
+		touchRecord(md, updateValues)
+		err = recordDB(tx, op, page).Where("id = ?", id).Updates(updateValues).Error
+		if err != nil {
+			return err
+		}
+		if err = checkDataPermission(tx, op, page, id); err != nil {
+			return err
+		}
+		if err = saveChildren(tx, op, md, id, m); err != nil {
+			return err
+		}
+		if err = runRecordHooks(tx, op, page, HookAfterUpdate, id, m, before); err != nil {
+			return err
+		}
+		if err = writeAudit(tx, op, page, AuditActionUpdate, id, before); err != nil {
+			return err
+		}
+		return writeOutbox(tx, op, page, AuditActionUpdate, id, nil)
+	})
+}
+
+// Patch 只更新m中传入的字段,没有传入的字段保持不变
+func Patch(op *Operator, pageName string, m map[string]interface{}) error {
+	page, err := GetPageByName(pageName)
+	if err != nil {
+		return err
+	}
+	data := make(map[string]interface{})
+	var id interface{}
+	for key, value := range m {
+		if strings.EqualFold(key, "id") {
+			id = value
+			continue
 		}
+		data[key] = value
 	}
-	return dbClient.DB().Exec(fmt.Sprintf("UPDATE %s SET %s where id=?", NamingStrategy.TableName(page.Metadata.Name), strings.Join(updateFields, ",")), updateValues...).Error
+	if id == nil {
+		return errors.New("id不能为空")
+	}
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		return updateFieldsByID(tx, op, page, fmt.Sprint(id), data)
+	})
 }
 
-func GetDetailByName(pageName, name string) (map[string]interface{}, error) {
+func GetDetailByName(op *Operator, pageName, name string, expand, expandMode string) (map[string]interface{}, error) {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return nil, err
 	}
 	data := make(map[string]interface{})
 	result := make(map[string]interface{})
-	err = dbClient.DB().Raw(fmt.Sprintf("select * from %s where name=?", NamingStrategy.TableName(page.Metadata.Name)), name).Scan(&result).Error
+	err = recordDB(dbClient.DB(), op, page).Where("name = ?", name).Limit(1).Find(&result).Error
 	if err != nil {
 		return nil, err
 	}
 	for key, value := range result {
 		data[CamelName2(key)] = value
 	}
-	return data, nil
-}
-
-func Copy(pageName string, id string) error {
-	from, err := GetDetailById(pageName, id)
-	if err != nil {
-		return err
+	stripHiddenFields(fieldPermissions(op, page), data)
+	if err = revealRecords(op, page.Metadata, data); err != nil {
+		return nil, err
 	}
-
-	from["ID"] = 0
-	if _, ok := from["name"]; ok {
-		from["name"] = fmt.Sprintf("%s Copy", from["name"])
+	if len(data) > 0 {
+		err = ExpandRecords(op, page, []map[string]interface{}{data}, expand, expandMode)
 	}
-	fmt.Println(from)
-	return Create(pageName, from)
+	return data, err
 }
 
-func Enable(pageName string, id string, enable bool) error {
+func Copy(op *Operator, pageName string, id string) error {
 	page, err := GetPageByName(pageName)
 	if err != nil {
 		return err
 	}
-	return dbClient.DB().Table(NamingStrategy.TableName(page.Metadata.Name)).Where("id=?", id).Update("enable", enable).Error
-}
-
-func GetTree(pageName string) (list []map[string]interface{}, total int64, err error) {
-	page, err := GetPageByName(pageName)
+	md := page.Metadata
+	row := make(map[string]interface{})
+	err = recordDB(dbClient.DB(), op, page).Where("id = ?", id).Limit(1).Find(&row).Error
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
-
-	var menuList []map[string]interface{}
-	treeMap, err := GetTreeMap(page)
-	menuList = treeMap[""]
-	for i := 0; i < len(menuList); i++ {
-		err = GetChildrenList(menuList[i], treeMap)
+	// 不在当前用户的租户或者数据权限范围内时查询不到记录
+	if len(row) == 0 {
+		return ErrRecordNotExist
 	}
-	return menuList, total, err
-}
-
-func GetTreeMap(page *Page) (treeMap map[string][]map[string]interface{}, err error) {
-	var all []map[string]interface{}
-	treeMap = make(map[string][]map[string]interface{})
-	err = dbClient.DB().Table(NamingStrategy.TableName(page.Metadata.Name)).Order("level").Find(&all).Error
-	for _, v := range all {
-		d := make(map[string]interface{})
-		for key, value := range v {
-			d[CamelName2(key)] = value
+	// 脱敏后的值不能直接复制,使用数据库中的记录解密后的明文,没有写权限的字段在新增时会被忽略。
+	// 主键和编码规则字段在新增时重新生成
+	from := make(map[string]interface{})
+	for _, field := range md.MetadataFields {
+		value, ok := row[LowerSnakeCase(field.Name)]
+		if !ok || strings.EqualFold(field.Name, "id") || field.CodeRule != "" || IsRelationField(md, field) {
+			continue
 		}
-		parentID, ok := d["parentID"]
-		if !ok {
-			return nil, fmt.Errorf("不是树形接口的数据,不存在parentID")
+		value, err = plainValue(field, derefValue(value))
+		if err != nil {
+			return err
+		}
+		if b, ok := value.([]byte); ok {
+			value = string(b)
 		}
-		d["key"] = d["ID"]
-		d["title"] = d["name"]
-		treeMap[parentID.(string)] = append(treeMap[parentID.(string)], d)
+		from[field.Name] = value
+	}
+	if _, ok := from["name"]; ok {
+		from["name"] = fmt.Sprintf("%s Copy", from["name"])
 	}
-	return treeMap, err
+	return Create(op, pageName, from)
 }
 
-func GetChildrenList(location map[string]interface{}, treeMap map[string][]map[string]interface{}) (err error) {
-	id, ok := location["ID"]
-	if !ok {
-		return fmt.Errorf("不是树形接口的数据,不存在ID")
-	}
-	children, ok := treeMap[id.(string)]
-	if !ok {
-		return nil
-	}
-	location["children"] = children
-	for i := 0; i < len(children); i++ {
-		err = GetChildrenList(children[i], treeMap)
+func Enable(op *Operator, pageName string, id string, enable bool) error {
+	page, err := GetPageByName(pageName)
+	if err != nil {
+		return err
 	}
-	return err
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		return enableByID(tx, op, page, id, enable)
+	})
 }
@@ -0,0 +1,299 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/CloudSilk/pkg/model"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+// aggregateFuncs 支持的统计函数
+var aggregateFuncs = map[string]string{
+	"count": "COUNT",
+	"sum":   "SUM",
+	"avg":   "AVG",
+	"min":   "MIN",
+	"max":   "MAX",
+}
+
+// bucketFormats 不同数据库按天、周、月格式化日期的表达式,%s是字段名
+var bucketFormats = map[string]map[string]string{
+	"mysql": {
+		BucketDay:   "DATE_FORMAT(%s, '%%Y-%%m-%%d')",
+		BucketWeek:  "DATE_FORMAT(%s, '%%x-%%v')",
+		BucketMonth: "DATE_FORMAT(%s, '%%Y-%%m')",
+	},
+	"sqlite": {
+		BucketDay:   "strftime('%%Y-%%m-%%d', %s)",
+		BucketWeek:  "strftime('%%Y-%%W', %s)",
+		BucketMonth: "strftime('%%Y-%%m', %s)",
+	},
+	"postgres": {
+		BucketDay:   "to_char(%s, 'YYYY-MM-DD')",
+		BucketWeek:  "to_char(%s, 'IYYY-IW')",
+		BucketMonth: "to_char(%s, 'YYYY-MM')",
+	},
+}
+
+type AggregateRequest struct {
+	PageName string                 `json:"pageName" form:"pageName" uri:"pageName"`
+	Data     map[string]interface{} `json:"data" form:"data" uri:"data"`
+	// Keyword 在所有开启了Like的字段中模糊查询
+	Keyword string `json:"keyword" form:"keyword" uri:"keyword"`
+	// GroupBy 分组字段,多个使用逗号隔开,日期字段可以加:day、:week、:month按天、周、月分组,例如status,createdAt:month
+	GroupBy string `json:"groupBy" form:"groupBy" uri:"groupBy"`
+	// Metrics 统计函数,多个使用逗号隔开,格式为函数:字段,支持count、sum、avg、min、max,
+	// count可以不指定字段,例如count,sum:amount,不传时默认为count
+	Metrics  string    `json:"metrics" form:"metrics" uri:"metrics"`
+	Operator *Operator `json:"-" form:"-" uri:"-"`
+}
+
+type AggregateResponse struct {
+	model.CommonResponse
+	Data []map[string]interface{} `json:"data"`
+}
+
+// aggregateColumn 统计结果中的一列
+type aggregateColumn struct {
+	Expr  string
+	Alias string
+}
+
+// Aggregate 按照分组字段统计记录,查询条件和租户范围和Query一致,
+// 返回的每条记录包含分组字段和统计结果,例如{"status":1,"createdAtMonth":"2023-01","count":10,"sumAmount":100}
+func Aggregate(req *AggregateRequest, resp *AggregateResponse) {
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	md := readableMetadata(page.Metadata, fieldPermissions(req.Operator, page))
+	filters, err := ParseFilters(req.Operator, md, req.Data)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
+	groups, err := parseGroupBy(req.Operator, md, req.GroupBy)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
+	metrics, err := parseMetrics(req.Operator, md, req.Metrics)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
+	if err = checkAggregateAliases(groups, metrics); err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
+
+	var selects []string
+	for _, column := range append(groups, metrics...) {
+		selects = append(selects, column.Expr+" AS "+QuoteColumn(column.Alias))
+	}
+	db, err := ApplyKeyword(ApplyFilters(recordDB(dbClient.DB(), req.Operator, page), filters), req.Operator, md, req.Keyword)
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
+	db = db.Select(strings.Join(selects, ","))
+	for _, group := range groups {
+		db = db.Group(group.Expr).Order(group.Expr)
+	}
+	var rows []map[string]interface{}
+	if err = db.Find(&rows).Error; err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+
+	resp.Data = make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		d := make(map[string]interface{})
+		for _, group := range groups {
+			value := derefValue(row[group.Alias])
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			d[CamelName2(group.Alias)] = value
+		}
+		for _, metric := range metrics {
+			d[CamelName2(metric.Alias)] = metricValue(derefValue(row[metric.Alias]))
+		}
+		resp.Data[i] = d
+	}
+}
+
+// parseGroupBy 解析分组字段,日期字段按天、周、月分组时别名为<字段名>_<day|week|month>,
+// 分组的值会原样返回,所以加密字段和当前用户不能查看原值的脱敏字段不能分组
+// 同一个分组重复出现时返回错误
+func parseGroupBy(op *Operator, md *Metadata, groupBy string) ([]*aggregateColumn, error) {
+	var columns []*aggregateColumn
+	seen := make(map[string]bool)
+	add := func(column *aggregateColumn) error {
+		if seen[column.Alias] {
+			return fmt.Errorf("分组字段重复:%s", column.Alias)
+		}
+		seen[column.Alias] = true
+		columns = append(columns, column)
+		return nil
+	}
+	for _, item := range strings.Split(groupBy, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, bucket := item, ""
+		if i := strings.Index(item, ":"); i > 0 {
+			name, bucket = item[:i], item[i+1:]
+		}
+		field := md.FieldByName(name)
+		if field == nil || IsRelationField(md, field) {
+			return nil, fmt.Errorf("不存在字段:%s", name)
+		}
+		if field.Encrypt {
+			return nil, fmt.Errorf("加密字段%s不能分组", name)
+		}
+		if field.Mask != "" && !canUnmask(op, field) {
+			return nil, fmt.Errorf("脱敏字段%s不能分组", name)
+		}
+		column := LowerSnakeCase(field.Name)
+		if bucket == "" {
+			if err := add(&aggregateColumn{Expr: QuoteColumn(column), Alias: column}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !isDateType(field.Type) {
+			return nil, fmt.Errorf("字段%s不是日期类型,不能按%s分组", name, bucket)
+		}
+		formats, ok := bucketFormats[dbClient.DB().Dialector.Name()]
+		if !ok {
+			return nil, fmt.Errorf("数据库%s不支持按日期分组", dbClient.DB().Dialector.Name())
+		}
+		format, ok := formats[bucket]
+		if !ok {
+			return nil, fmt.Errorf("不支持的日期分组:%s", bucket)
+		}
+		if err := add(&aggregateColumn{Expr: fmt.Sprintf(format, QuoteColumn(column)), Alias: column + "_" + bucket}); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// parseMetrics 解析统计函数,别名为<函数>_<字段名>,count不指定字段时别名为count,
+// 同一个统计函数重复出现时只统计一次
+func parseMetrics(op *Operator, md *Metadata, metrics string) ([]*aggregateColumn, error) {
+	var columns []*aggregateColumn
+	seen := make(map[string]bool)
+	add := func(column *aggregateColumn) {
+		if !seen[column.Alias] {
+			seen[column.Alias] = true
+			columns = append(columns, column)
+		}
+	}
+	for _, item := range strings.Split(metrics, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		fn, name := item, ""
+		if i := strings.Index(item, ":"); i > 0 {
+			fn, name = item[:i], item[i+1:]
+		}
+		fn = strings.ToLower(fn)
+		sqlFn, ok := aggregateFuncs[fn]
+		if !ok {
+			return nil, fmt.Errorf("不支持的统计函数:%s", fn)
+		}
+		if name == "" {
+			if fn != "count" {
+				return nil, fmt.Errorf("统计函数%s必须指定字段", fn)
+			}
+			add(&aggregateColumn{Expr: "COUNT(*)", Alias: "count"})
+			continue
+		}
+		field := md.FieldByName(name)
+		if field == nil || IsRelationField(md, field) {
+			return nil, fmt.Errorf("不存在字段:%s", name)
+		}
+		if fn != "count" && field.Encrypt {
+			return nil, fmt.Errorf("加密字段%s只能使用count", name)
+		}
+		if fn != "count" && field.Mask != "" && !canUnmask(op, field) {
+			return nil, fmt.Errorf("脱敏字段%s只能使用count", name)
+		}
+		if fn != "count" && !isNumberType(field.Type) && !((fn == "min" || fn == "max") && isDateType(field.Type)) {
+			return nil, fmt.Errorf("字段%s不是数字类型,不能使用%s", name, fn)
+		}
+		column := LowerSnakeCase(field.Name)
+		add(&aggregateColumn{Expr: sqlFn + "(" + QuoteColumn(column) + ")", Alias: fn + "_" + column})
+	}
+	if len(columns) == 0 {
+		columns = append(columns, &aggregateColumn{Expr: "COUNT(*)", Alias: "count"})
+	}
+	return columns, nil
+}
+
+// checkAggregateAliases 统计结果和分组字段使用同一个名字返回时会互相覆盖,
+// 例如按count字段分组并且使用默认的count统计
+func checkAggregateAliases(groups, metrics []*aggregateColumn) error {
+	names := make(map[string]bool)
+	for _, group := range groups {
+		names[CamelName2(group.Alias)] = true
+	}
+	for _, metric := range metrics {
+		if names[CamelName2(metric.Alias)] {
+			return fmt.Errorf("统计结果%s和分组字段重名,请指定统计的字段,例如count:id", CamelName2(metric.Alias))
+		}
+	}
+	return nil
+}
+
+// metricValue MySQL的SUM和AVG返回的是字符串,转换成数字
+func metricValue(value interface{}) interface{} {
+	var str string
+	switch v := value.(type) {
+	case []byte:
+		str = string(v)
+	case string:
+		str = v
+	default:
+		return value
+	}
+	if f, err := strconv.ParseFloat(str, 64); err == nil {
+		return f
+	}
+	return str
+}
+
+func isNumberType(t string) bool {
+	switch t {
+	case "int", "int32", "int64", "bigint", "uint", "smallint", "float", "float32", "float64", "double", "decimal":
+		return true
+	}
+	return false
+}
+
+func isDateType(t string) bool {
+	switch t {
+	case "datetime", "date", "timestamp":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package model
+
+import "testing"
+
+func TestParseMetrics(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "name", Type: "varchar"}, {Name: "amount", Type: "decimal"}}}
+	columns, err := parseMetrics(nil, md, "")
+	if err != nil || len(columns) != 1 || columns[0].Alias != "count" {
+		t.Fatalf("expected default count, got %v %v", columns, err)
+	}
+	for _, metrics := range []string{"median:amount", "sum", "sum:name", "avg:password"} {
+		if _, err = parseMetrics(nil, md, metrics); err == nil {
+			t.Errorf("%s should fail", metrics)
+		}
+	}
+	if columns, err = parseMetrics(nil, md, "count,sum:amount,COUNT,sum:amount"); err != nil || len(columns) != 2 {
+		t.Fatalf("duplicate metrics should be merged, got %v %v", columns, err)
+	}
+}
+
+func TestCheckAggregateAliases(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "count", Type: "int"}, {Name: "id", Type: "bigint"}}}
+	groups, err := parseGroupBy(nil, md, "count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics, _ := parseMetrics(nil, md, "")
+	if err = checkAggregateAliases(groups, metrics); err == nil {
+		t.Fatal("default count should clash with field count")
+	}
+	metrics, _ = parseMetrics(nil, md, "count:id")
+	if err = checkAggregateAliases(groups, metrics); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMetricValue(t *testing.T) {
+	if v := metricValue([]byte("12.50")); v != 12.5 {
+		t.Fatalf("expected 12.5, got %v", v)
+	}
+	if v := metricValue("2023-01-01"); v != "2023-01-01" {
+		t.Fatalf("expected date string, got %v", v)
+	}
+	if v := metricValue(int64(3)); v != int64(3) {
+		t.Fatalf("expected 3, got %v", v)
+	}
+}
+
+func TestParseGroupBy(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{
+		{Name: "status", Type: "int"}, {Name: "idCard", Type: "varchar", Encrypt: true},
+		{Name: "phone", Type: "varchar", Mask: "phone", UnmaskRoles: "admin"},
+	}}
+	if columns, err := parseGroupBy(&Operator{RoleIDs: []string{"admin"}}, md, "status,phone"); err != nil || len(columns) != 2 {
+		t.Fatalf("unexpected columns %v %v", columns, err)
+	}
+	// 分组的值原样返回,不能查看原值的脱敏字段不能分组
+	for _, groupBy := range []string{"idCard", "phone", "status:day", "name", "status, status"} {
+		if _, err := parseGroupBy(&Operator{}, md, groupBy); err == nil {
+			t.Errorf("%s should fail", groupBy)
+		}
+	}
+}
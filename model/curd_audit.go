@@ -0,0 +1,236 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	apipb "github.com/CloudSilk/curd/proto"
+	"github.com/CloudSilk/pkg/model"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionEnable  = "enable"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+)
+
+// AuditLog 通用增删改查接口修改数据的记录,Changes只保存变化的字段
+type AuditLog struct {
+	model.TenantModel
+	PageName string         `json:"pageName" gorm:"size:100;index:audit_log_idx1"`
+	RecordID string         `json:"recordID" gorm:"size:36;index:audit_log_idx1"`
+	Action   string         `json:"action" gorm:"size:20;comment:create,update,delete,enable,restore,purge"`
+	UserID   string         `json:"userID" gorm:"size:36"`
+	UserName string         `json:"userName" gorm:"size:100"`
+	TransID  string         `json:"transID" gorm:"size:64"`
+	Changes  []*FieldChange `json:"changes" gorm:"type:text;serializer:json"`
+}
+
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type HistoryRequest struct {
+	model.CommonRequest
+	PageName string    `json:"pageName" form:"pageName" uri:"pageName"`
+	ID       string    `json:"id" form:"id" uri:"id"`
+	Operator *Operator `json:"-" form:"-" uri:"-"`
+}
+
+type HistoryResponse struct {
+	model.CommonResponse
+	Data []*AuditLog `json:"data"`
+}
+
+// History 分页查询记录的变更历史,按时间倒序
+func History(req *HistoryRequest, resp *HistoryResponse) {
+	if req.ID == "" {
+		resp.Code = model.BadRequest
+		resp.Message = "id不能为空"
+		return
+	}
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	if err = checkHistoryPermission(req.Operator, page, req.ID); err != nil {
+		resp.Code = int(ErrorCode(err, apipb.Code_InternalServerError))
+		resp.Message = err.Error()
+		return
+	}
+	db := dbClient.DB().Model(&AuditLog{}).Where("page_name = ? and record_id = ?", page.Name, req.ID)
+	db = ScopeTenant(db, req.Operator, page.Metadata)
+	resp.Total, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.Current, "created_at desc", &resp.Data, nil)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	// 不返回没有权限查看的字段和盲索引的变化,加密字段解密后按照当前用户的权限脱敏
+	permissions := fieldPermissions(req.Operator, page)
+	for _, log := range resp.Data {
+		before := make(map[string]interface{})
+		after := make(map[string]interface{})
+		for _, change := range log.Changes {
+			before[change.Field] = change.Before
+			after[change.Field] = change.After
+		}
+		stripHiddenFields(permissions, before, after)
+		if err = revealRecords(req.Operator, page.Metadata, before, after); err != nil {
+			resp.Code = model.InternalServerError
+			resp.Message = err.Error()
+			return
+		}
+		var changes []*FieldChange
+		for _, change := range log.Changes {
+			if _, ok := after[change.Field]; !ok {
+				continue
+			}
+			change.Before, change.After = before[change.Field], after[change.Field]
+			changes = append(changes, change)
+		}
+		log.Changes = changes
+	}
+}
+
+// checkHistoryPermission 只能查看数据权限范围内的记录的变更历史,包括回收站中的记录
+func checkHistoryPermission(op *Operator, page *Page, id string) error {
+	sql, _, err := dataRuleSQL(op, page)
+	if err != nil || sql == "" {
+		return err
+	}
+	var count int64
+	if err = ScopeDataRules(tableDB(dbClient.DB(), op, page), op, page).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNoDataPermission
+	}
+	return nil
+}
+
+// auditSnapshot 返回记录修改前的数据,页面没有开启变更历史时返回nil
+func auditSnapshot(tx *gorm.DB, page *Page, id interface{}) (map[string]interface{}, error) {
+	if !page.EnableAudit || id == nil {
+		return nil, nil
+	}
+	return loadRecord(tx, page, id)
+}
+
+// loadRecord 读取记录的原始数据,包括已经删除的记录
+func loadRecord(tx *gorm.DB, page *Page, id interface{}) (map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	err := tx.Table(NamingStrategy.TableName(page.Metadata.Name)).Where("id = ?", id).Limit(1).Find(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return rows[0], nil
+}
+
+// writeAudit 读取记录修改后的数据,和修改前的数据比较后保存变更历史
+func writeAudit(tx *gorm.DB, op *Operator, page *Page, action string, id interface{}, before map[string]interface{}) error {
+	if !page.EnableAudit || id == nil {
+		return nil
+	}
+	after, err := loadRecord(tx, page, id)
+	if err != nil {
+		return err
+	}
+	changes, err := diffRecords(page.Metadata, before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 && action == AuditActionUpdate {
+		return nil
+	}
+	log := &AuditLog{
+		PageName: page.Name,
+		RecordID: fmt.Sprint(id),
+		Action:   action,
+		Changes:  changes,
+	}
+	if op != nil {
+		log.TenantID = op.TenantID
+		log.UserID = op.UserID
+		log.UserName = op.UserName
+		log.TransID = op.TransID
+	}
+	// 变更历史属于记录所在的租户
+	for _, row := range []map[string]interface{}{after, before} {
+		if tenantID, ok := row[TenantColumn]; ok && tenantID != nil {
+			log.TenantID = fmt.Sprint(auditValue(tenantID))
+			break
+		}
+	}
+	return tx.Create(log).Error
+}
+
+// diffRecords 比较修改前后的原始数据。加密字段每次加密的结果都不一样,需要比较解密后的值,
+// 变更中仍然保存加密后的值,查询时再解密和脱敏;盲索引随加密字段变化,不记录
+func diffRecords(md *Metadata, before, after map[string]interface{}) ([]*FieldChange, error) {
+	// 更新时间每次都会变化,没有必要记录
+	skipped := map[string]bool{UpdatedAtColumn: true}
+	encrypted := make(map[string]*MetadataField)
+	for _, field := range md.MetadataFields {
+		if index := blindIndexField(md, field); index != nil {
+			skipped[LowerSnakeCase(index.Name)] = true
+		}
+		if field.Encrypt {
+			encrypted[LowerSnakeCase(field.Name)] = field
+		}
+	}
+	columns := make(map[string]bool)
+	for column := range before {
+		columns[column] = true
+	}
+	for column := range after {
+		columns[column] = true
+	}
+	var changes []*FieldChange
+	for column := range columns {
+		if skipped[column] {
+			continue
+		}
+		oldValue, newValue := auditValue(before[column]), auditValue(after[column])
+		oldPlain, newPlain := oldValue, newValue
+		if field, ok := encrypted[column]; ok {
+			var err error
+			if oldPlain, err = plainValue(field, oldValue); err != nil {
+				return nil, fmt.Errorf("字段%s%w", field.Name, err)
+			}
+			if newPlain, err = plainValue(field, newValue); err != nil {
+				return nil, fmt.Errorf("字段%s%w", field.Name, err)
+			}
+		}
+		if reflect.DeepEqual(oldPlain, newPlain) {
+			continue
+		}
+		changes = append(changes, &FieldChange{Field: CamelName2(column), Before: oldValue, After: newValue})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
+
+// auditValue 把数据库读取的值转换成可以比较和序列化的值
+func auditValue(value interface{}) interface{} {
+	switch v := derefValue(value).(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	default:
+		return v
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffRecords(t *testing.T) {
+	name := "tom"
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.Local)
+	before := map[string]interface{}{"id": int64(1), "name": &name, "level": int64(1), "updated_at": now}
+	after := map[string]interface{}{"id": int64(1), "name": "tom", "level": int64(2), "updated_at": now.Add(time.Hour), "deleted_at": &now}
+
+	md := &Metadata{}
+	changes, err := diffRecords(md, before, after)
+	if err != nil || len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d %v", len(changes), err)
+	}
+	if changes[0].Field != "deletedAt" || changes[0].Before != nil || changes[0].After != "2023-01-02 03:04:05" {
+		t.Fatalf("unexpected change %+v", changes[0])
+	}
+	if changes[1].Field != "level" || changes[1].Before != int64(1) || changes[1].After != int64(2) {
+		t.Fatalf("unexpected change %+v", changes[1])
+	}
+
+	if changes, _ = diffRecords(md, nil, map[string]interface{}{"name": "a"}); len(changes) != 1 || changes[0].After != "a" {
+		t.Fatalf("unexpected changes for create %+v", changes)
+	}
+}
@@ -0,0 +1,385 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/CloudSilk/pkg/model"
+	"gorm.io/gorm"
+)
+
+type BatchRequest struct {
+	PageName string                 `json:"pageName"`
+	IDs      []string               `json:"ids"`
+	Enable   bool                   `json:"enable"`
+	Data     map[string]interface{} `json:"data"`
+	Operator *Operator              `json:"-"`
+}
+
+// BatchFailure 批量操作中失败的记录
+type BatchFailure struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+type BatchResponse struct {
+	model.CommonResponse
+	Failures []*BatchFailure `json:"failures"`
+}
+
+var ErrRecordNotExist = errors.New("记录不存在")
+
+var ErrEnableNotSupported = errors.New("元数据没有enable字段,不支持启用和禁用")
+
+// IsEnableMetadata 元数据中有enable字段时才能启用和禁用
+func IsEnableMetadata(md *Metadata) bool {
+	return md.FieldByName("enable") != nil
+}
+
+func BatchDelete(req *BatchRequest, resp *BatchResponse) {
+	runBatch(req, resp, nil, func(tx *gorm.DB, page *Page, id string) error {
+		return deleteByID(tx, req.Operator, page, id)
+	})
+}
+
+func BatchEnable(req *BatchRequest, resp *BatchResponse) {
+	check := func(page *Page) error {
+		if !IsEnableMetadata(page.Metadata) {
+			return ErrEnableNotSupported
+		}
+		return nil
+	}
+	runBatch(req, resp, check, func(tx *gorm.DB, page *Page, id string) error {
+		return enableByID(tx, req.Operator, page, id, req.Enable)
+	})
+}
+
+func BatchUpdate(req *BatchRequest, resp *BatchResponse) {
+	if len(req.Data) == 0 {
+		resp.Code = model.BadRequest
+		resp.Message = "更新的字段不能为空"
+		return
+	}
+	runBatch(req, resp, nil, func(tx *gorm.DB, page *Page, id string) error {
+		// 保存子表时会给子记录写入外键和id,每条记录使用单独的数据
+		return updateFieldsByID(tx, req.Operator, page, id, cloneRecord(req.Data))
+	})
+}
+
+// runBatch 在同一个事务里面处理所有记录,每条记录使用单独的SavePoint,
+// 只要有一条记录失败就回滚整个事务并返回失败的记录。check不为nil时先检查页面是否支持这个操作
+func runBatch(req *BatchRequest, resp *BatchResponse, check func(page *Page) error, fn func(tx *gorm.DB, page *Page, id string) error) {
+	if len(req.IDs) == 0 {
+		resp.Code = model.BadRequest
+		resp.Message = "ids不能为空"
+		return
+	}
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	if check != nil {
+		if err = check(page); err != nil {
+			resp.Code = model.BadRequest
+			resp.Message = err.Error()
+			return
+		}
+	}
+	err = dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		for _, id := range req.IDs {
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return fn(tx, page, id)
+			})
+			if err != nil {
+				resp.Failures = append(resp.Failures, &BatchFailure{ID: id, Message: err.Error()})
+			}
+		}
+		if len(resp.Failures) > 0 {
+			return fmt.Errorf("%d条记录处理失败", len(resp.Failures))
+		}
+		return nil
+	})
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+}
+
+// cloneRecord 深拷贝提交的记录,包括子表的记录
+func cloneRecord(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		out[key] = cloneValue(value)
+	}
+	return out
+}
+
+func cloneValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return cloneRecord(v)
+	case []map[string]interface{}:
+		list := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			list[i] = cloneRecord(item)
+		}
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = cloneValue(item)
+		}
+		return list
+	}
+	return value
+}
+
+func existsByID(tx *gorm.DB, op *Operator, page *Page, id interface{}) error {
+	var count int64
+	err := recordDB(tx, op, page).Where("id = ?", id).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrRecordNotExist
+	}
+	return nil
+}
+
+func deleteByID(tx *gorm.DB, op *Operator, page *Page, id string) error {
+	before, err := auditSnapshot(tx, page, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := outboxSnapshot(tx, page, id)
+	if err != nil {
+		return err
+	}
+	var hookData map[string]interface{}
+	if hasHooks(page, HookBeforeDelete) || hasHooks(page, HookAfterDelete) {
+		row, err := lockRecord(tx, op, page, id)
+		if err != nil {
+			return err
+		}
+		hookData = hookRecord(page.Metadata, row)
+		if err = runHooks(&HookContext{Tx: tx, Operator: op, Page: page, Event: HookBeforeDelete, ID: id, Data: hookData, Before: hookData}); err != nil {
+			return err
+		}
+	}
+	if IsSoftDeleteMetadata(page.Metadata) {
+		err = softDeleteByID(tx, op, page, id)
+	} else {
+		err = hardDeleteByID(recordDB(tx, op, page), id)
+		if err == nil {
+			err = deleteChildren(tx, op, page.Metadata, id)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if hookData != nil {
+		if err = runHooks(&HookContext{Tx: tx, Operator: op, Page: page, Event: HookAfterDelete, ID: id, Data: hookData, Before: hookData}); err != nil {
+			return err
+		}
+	}
+	if err = writeAudit(tx, op, page, AuditActionDelete, id, before); err != nil {
+		return err
+	}
+	return writeOutbox(tx, op, page, AuditActionDelete, id, deleted)
+}
+
+func hardDeleteByID(db *gorm.DB, id string) error {
+	result := db.Where("id = ?", id).Delete(map[string]interface{}{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotExist
+	}
+	return nil
+}
+
+func enableByID(tx *gorm.DB, op *Operator, page *Page, id string, enable bool) error {
+	if !IsEnableMetadata(page.Metadata) {
+		return ErrEnableNotSupported
+	}
+	if err := existsByID(tx, op, page, id); err != nil {
+		return err
+	}
+	before, err := auditSnapshot(tx, page, id)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{"enable": enable}
+	touchRecord(page.Metadata, values)
+	err = recordDB(tx, op, page).Where("id=?", id).Updates(values).Error
+	if err != nil {
+		return err
+	}
+	return writeAudit(tx, op, page, AuditActionEnable, id, before)
+}
+
+// updateFieldsByID 只更新data中的字段,和原记录合并后再校验和检查唯一字段是否重复
+func updateFieldsByID(tx *gorm.DB, op *Operator, page *Page, id string, data map[string]interface{}) error {
+	return updateSystemFieldsByID(tx, op, page, id, data, nil)
+}
+
+// updateSystemFieldsByID 和updateFieldsByID一样,system中是系统维护的字段,例如树的level,
+// 不检查字段权限,和data在同一次更新中保存,这样审计和推送里能看到这些字段的变化
+func updateSystemFieldsByID(tx *gorm.DB, op *Operator, page *Page, id string, data, system map[string]interface{}) error {
+	md := page.Metadata
+	if err := readOnlyError(md, fieldPermissions(op, page), data); err != nil {
+		return err
+	}
+	record := make(map[string]interface{})
+	for key, value := range system {
+		field := md.FieldByName(key)
+		if field == nil {
+			return fmt.Errorf("不存在字段:%s", key)
+		}
+		record[field.Name] = value
+	}
+	for key, value := range data {
+		field := md.FieldByName(key)
+		if field == nil {
+			return fmt.Errorf("不存在字段:%s", key)
+		}
+		// 子表在更新完记录后单独保存
+		if field.IsArray && field.RefMetadata != "" {
+			continue
+		}
+		if strings.EqualFold(field.Name, "id") {
+			return errors.New("不能修改id")
+		}
+		if LowerSnakeCase(field.Name) == TenantColumn && IsTenantMetadata(md) {
+			return errors.New("不能修改租户")
+		}
+		if IsRelationField(md, field) {
+			return fmt.Errorf("关联字段%s不能直接修改", key)
+		}
+		record[field.Name] = value
+	}
+	current, err := lockRecord(tx, op, page, id)
+	if err != nil {
+		return err
+	}
+	if err = checkRecordVersion(md, current, record); err != nil {
+		return err
+	}
+	if err = keepMaskedValues(op, md, record, current); err != nil {
+		return err
+	}
+	// 和原记录合并后再校验,保证更新后的记录是完整有效的
+	merged := make(map[string]interface{})
+	for _, field := range md.MetadataFields {
+		if value, ok := current[LowerSnakeCase(field.Name)]; ok {
+			if merged[field.Name], err = plainValue(field, value); err != nil {
+				return err
+			}
+		}
+	}
+	for name, value := range record {
+		merged[name] = value
+	}
+	if hasHooks(page, HookBeforeUpdate) {
+		submitted := make(map[string]interface{}, len(merged))
+		for name, value := range merged {
+			submitted[name] = value
+		}
+		if err = runRecordHooks(tx, op, page, HookBeforeUpdate, id, merged, current); err != nil {
+			return err
+		}
+		// before钩子修改的字段也需要保存
+		for name, value := range merged {
+			if old, ok := submitted[name]; !ok || !reflect.DeepEqual(old, value) {
+				record[name] = value
+			}
+		}
+	}
+	if err = ValidateRecord(tx, op, md, merged, false); err != nil {
+		return err
+	}
+	if err = setBlindIndexes(md, record); err != nil {
+		return err
+	}
+	for _, field := range md.MetadataFields {
+		if index := blindIndexField(md, field); index != nil {
+			if _, ok := record[field.Name]; ok {
+				merged[index.Name] = record[index.Name]
+			}
+		}
+	}
+	values := make(map[string]interface{})
+	checkUnique := false
+	for name := range record {
+		// before钩子可能加入不是元数据字段的key,不保存
+		field := md.FieldByName(name)
+		if field == nil {
+			continue
+		}
+		column := LowerSnakeCase(field.Name)
+		if isLockColumn(column) || column == CreatedAtColumn || column == DeletedAtColumn {
+			continue
+		}
+		values[column] = merged[name]
+		checkUnique = checkUnique || field.Unique
+	}
+
+	if checkUnique {
+		uniqueFields := []string{"id <> ?"}
+		fieldValues := []interface{}{id}
+		for _, field := range md.MetadataFields {
+			if !field.Unique {
+				continue
+			}
+			unique, err := uniqueField(md, field)
+			if err != nil {
+				return err
+			}
+			column := LowerSnakeCase(unique.Name)
+			value, ok := values[column]
+			if !ok {
+				value = current[column]
+			}
+			uniqueFields = append(uniqueFields, QuoteColumn(column)+" = ?")
+			fieldValues = append(fieldValues, value)
+		}
+		duplication, err := dbClient.CheckDuplication(uniqueDB(tx, op, page), strings.Join(uniqueFields, " and "), fieldValues...)
+		if err != nil {
+			return err
+		}
+		if duplication {
+			return errors.New("存在相同" + page.Title)
+		}
+	}
+	if err = encryptColumns(md, values); err != nil {
+		return err
+	}
+	if len(values) > 0 {
+		touchRecord(md, values)
+		err = recordDB(tx, op, page).Where("id = ?", id).Updates(values).Error
+		if err != nil {
+			return err
+		}
+		if err = checkDataPermission(tx, op, page, id); err != nil {
+			return err
+		}
+	}
+	if err = saveChildren(tx, op, md, id, data); err != nil {
+		return err
+	}
+	if err = runRecordHooks(tx, op, page, HookAfterUpdate, id, merged, current); err != nil {
+		return err
+	}
+	if err = writeAudit(tx, op, page, AuditActionUpdate, id, current); err != nil {
+		return err
+	}
+	return writeOutbox(tx, op, page, AuditActionUpdate, id, nil)
+}
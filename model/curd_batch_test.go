@@ -0,0 +1,48 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	apipb "github.com/CloudSilk/curd/proto"
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestCloneRecord(t *testing.T) {
+	m := map[string]interface{}{"name": "a", "items": []interface{}{map[string]interface{}{"name": "b"}},
+		"lines": []map[string]interface{}{{"name": "c"}}}
+	out := cloneRecord(m)
+	out["items"].([]interface{})[0].(map[string]interface{})["id"] = 1
+	out["lines"].([]map[string]interface{})[0]["id"] = 2
+	if _, ok := m["items"].([]interface{})[0].(map[string]interface{})["id"]; ok {
+		t.Fatalf("items should be copied: %v", m)
+	}
+	if _, ok := m["lines"].([]map[string]interface{})[0]["id"]; ok {
+		t.Fatalf("lines should be copied: %v", m)
+	}
+}
+
+func TestBatchEnableNotSupported(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "batch.db"), false), true)
+
+	md := &Metadata{Name: "BatchNote", MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}}}
+	if err := dbClient.DB().Create(&Page{Name: "batch_note", Title: "笔记", Enable: true, Metadata: md}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := dbClient.DB().Exec("CREATE TABLE batch_notes(id integer primary key autoincrement, name varchar(20))").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := Create(nil, "batch_note", map[string]interface{}{"name": "a"}); err != nil {
+		t.Fatal(err)
+	}
+	resp := &BatchResponse{}
+	BatchEnable(&BatchRequest{PageName: "batch_note", IDs: []string{"1"}, Enable: true}, resp)
+	if resp.Code != 40000 || len(resp.Failures) != 0 {
+		t.Fatalf("expected bad request, got %d %s %v", resp.Code, resp.Message, resp.Failures)
+	}
+	if err := Enable(nil, "batch_note", "1", true); ErrorCode(err, apipb.Code_InternalServerError) != apipb.Code_BadRequest {
+		t.Fatalf("expected bad request, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// childPage 子表没有单独的页面配置,使用子表的元数据构造一个页面
+func childPage(md *Metadata) *Page {
+	return &Page{Name: md.Name, Title: md.Name, Metadata: md}
+}
+
+// childItems 把请求中的子表数据转换成记录列表
+func childItems(value interface{}) ([]map[string]interface{}, error) {
+	switch v := value.(type) {
+	case []map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		items := make([]map[string]interface{}, len(v))
+		for i, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("第%d行格式错误", i+1)
+			}
+			items[i] = m
+		}
+		return items, nil
+	}
+	return nil, errors.New("必须是数组")
+}
+
+// saveChildren 保存m中的子表(IsArray并且引用其他元数据的字段)数据,
+// m中没有子表字段或者值为null时不修改子表;否则有ID的记录更新,没有ID的记录新增,
+// 不在列表中的子表记录会被删除,和UpdatePage处理PageField的方式一致
+func saveChildren(tx *gorm.DB, op *Operator, md *Metadata, parentID interface{}, m map[string]interface{}) error {
+	for _, field := range md.MetadataFields {
+		if !field.IsArray || field.RefMetadata == "" {
+			continue
+		}
+		value, ok := m[field.Name]
+		if !ok || value == nil {
+			continue
+		}
+		items, err := childItems(value)
+		if err != nil {
+			return fmt.Errorf("%s%s", fieldTitle(field), err.Error())
+		}
+		if parentID == nil {
+			return errors.New("无法获取新增记录的ID,不能保存" + fieldTitle(field))
+		}
+		childMD, err := GetMetadataById(field.RefMetadata)
+		if err != nil {
+			return err
+		}
+		fkField := childKeyField(md, childMD)
+		if fkField == nil {
+			return fmt.Errorf("子表%s没有关联字段%sID", childMD.Name, md.Name)
+		}
+		page := childPage(childMD)
+		idName := "id"
+		if idField := childMD.FieldByName("id"); idField != nil {
+			idName = idField.Name
+		}
+
+		var oldIDs []string
+		err = recordDB(tx, op, page).Where(QuoteColumn(LowerSnakeCase(fkField.Name))+" = ?", parentID).Pluck("id", &oldIDs).Error
+		if err != nil {
+			return err
+		}
+		exists := make(map[string]bool)
+		for _, id := range oldIDs {
+			exists[id] = true
+		}
+
+		keep := make(map[string]bool)
+		for i, item := range items {
+			item[fkField.Name] = parentID
+			id := derefValue(item[idName])
+			if isEmptyValue(id) {
+				delete(item, idName)
+				err = createRecord(tx, op, page, item)
+			} else if exists[fmt.Sprint(id)] {
+				keep[fmt.Sprint(id)] = true
+				data := make(map[string]interface{})
+				for key, value := range item {
+					if key != idName {
+						data[key] = value
+					}
+				}
+				err = updateFieldsByID(tx, op, page, fmt.Sprint(id), data)
+			} else {
+				err = fmt.Errorf("记录%v不属于当前记录", id)
+			}
+			if err != nil {
+				return childError(field, i, err)
+			}
+		}
+		for _, id := range oldIDs {
+			if keep[id] {
+				continue
+			}
+			if err = deleteByID(tx, op, page, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// childError 给子表的错误加上行号,校验错误的字段名使用lines[0].name的格式
+func childError(field *MetadataField, index int, err error) error {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return fmt.Errorf("%s第%d行:%w", fieldTitle(field), index+1, err)
+	}
+	result := ValidationErrors{}
+	for name, message := range errs {
+		result[fmt.Sprintf("%s[%d].%s", field.Name, index, name)] = fmt.Sprintf("%s第%d行:%s", fieldTitle(field), index+1, message)
+	}
+	return result
+}
+
+// deleteChildren 彻底删除记录时同时删除所有子表记录
+func deleteChildren(tx *gorm.DB, op *Operator, md *Metadata, parentID interface{}) error {
+	for _, field := range md.MetadataFields {
+		if !field.IsArray || field.RefMetadata == "" {
+			continue
+		}
+		childMD, err := GetMetadataById(field.RefMetadata)
+		if err != nil {
+			return err
+		}
+		fkField := childKeyField(md, childMD)
+		if fkField == nil {
+			continue
+		}
+		page := childPage(childMD)
+		fk := QuoteColumn(LowerSnakeCase(fkField.Name)) + " = ?"
+		var ids []string
+		if err = tableDB(tx, op, page).Where(fk, parentID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err = deleteChildren(tx, op, childMD, id); err != nil {
+				return err
+			}
+		}
+		if err = tableDB(tx, op, page).Where(fk, parentID).Delete(map[string]interface{}{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
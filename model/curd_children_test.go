@@ -0,0 +1,126 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestChildItems(t *testing.T) {
+	items, err := childItems([]interface{}{map[string]interface{}{"sku": "a"}})
+	if err != nil || len(items) != 1 || items[0]["sku"] != "a" {
+		t.Fatalf("unexpected items %v %v", items, err)
+	}
+	if _, err = childItems([]interface{}{"a"}); err == nil {
+		t.Fatal("non object item should fail")
+	}
+	if _, err = childItems("a"); err == nil {
+		t.Fatal("non array value should fail")
+	}
+}
+
+func TestChildError(t *testing.T) {
+	field := &MetadataField{Name: "lines", DisplayName: "明细"}
+	err := childError(field, 1, ValidationErrors{"sku": "SKU不能为空"})
+	errs, ok := err.(ValidationErrors)
+	if !ok || errs["lines[1].sku"] != "明细第2行:SKU不能为空" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	err = childError(field, 0, ErrRecordNotExist)
+	if !errors.Is(err, ErrRecordNotExist) || err.Error() != "明细第1行:记录不存在" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestSaveChildren(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "children.db"), false), true)
+
+	lineMD := &Metadata{Name: "BillLine", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "billID", Type: "bigint"}, {Name: "sku", Type: "varchar", NotNull: true},
+	}}
+	if err := dbClient.DB().Create(lineMD).Error; err != nil {
+		t.Fatal(err)
+	}
+	md := &Metadata{Name: "Bill", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}, {Name: "lines", IsArray: true, RefMetadata: lineMD.ID},
+	}}
+	if err := dbClient.DB().Create(&Page{Name: "bill", Title: "单据", Enable: true, Metadata: md}).Error; err != nil {
+		t.Fatal(err)
+	}
+	dbClient.DB().Exec("CREATE TABLE bills(id integer primary key autoincrement, name varchar(20))")
+	dbClient.DB().Exec("CREATE TABLE bill_lines(id integer primary key autoincrement, bill_id bigint, sku varchar(20))")
+	op := SystemOperator()
+	lines := func(billID int) string {
+		var skus []string
+		dbClient.DB().Table("bill_lines").Where("bill_id = ?", billID).Order("id").Pluck("sku", &skus)
+		return fmt.Sprint(skus)
+	}
+	count := func(table string) (n int64) {
+		dbClient.DB().Table(table).Count(&n)
+		return
+	}
+
+	// 子表保存失败时主表记录也要回滚
+	err := Create(op, "bill", map[string]interface{}{"name": "b0", "lines": []interface{}{
+		map[string]interface{}{"sku": "a"}, map[string]interface{}{},
+	}})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || errs["lines[1].sku"] == "" {
+		t.Fatalf("expected validation error for lines[1].sku, got %v", err)
+	}
+	if count("bills") != 0 || count("bill_lines") != 0 {
+		t.Fatalf("create should be rolled back, got %d bills %d lines", count("bills"), count("bill_lines"))
+	}
+
+	for _, name := range []string{"b1", "b2"} {
+		err = Create(op, "bill", map[string]interface{}{"name": name, "lines": []interface{}{
+			map[string]interface{}{"sku": name + "-a"}, map[string]interface{}{"sku": name + "-b"},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lines(1) != "[b1-a b1-b]" || lines(2) != "[b2-a b2-b]" {
+		t.Fatalf("unexpected lines %s %s", lines(1), lines(2))
+	}
+
+	// 有ID的更新,没有ID的新增,不在列表中的删除
+	err = Patch(op, "bill", map[string]interface{}{"id": 1, "lines": []interface{}{
+		map[string]interface{}{"id": 1, "sku": "b1-c"}, map[string]interface{}{"sku": "b1-d"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines(1) != "[b1-c b1-d]" || lines(2) != "[b2-a b2-b]" {
+		t.Fatalf("unexpected lines after update %s %s", lines(1), lines(2))
+	}
+	// 其他记录的子表记录不能通过当前记录修改
+	err = Patch(op, "bill", map[string]interface{}{"id": 1, "lines": []interface{}{map[string]interface{}{"id": 3, "sku": "x"}}})
+	if err == nil || lines(2) != "[b2-a b2-b]" {
+		t.Fatalf("expected error for line of other bill, got %v %s", err, lines(2))
+	}
+
+	// 批量修改时每条记录都保存自己的子表记录
+	resp := &BatchResponse{}
+	BatchUpdate(&BatchRequest{PageName: "bill", IDs: []string{"1", "2"}, Operator: op,
+		Data: map[string]interface{}{"lines": []interface{}{map[string]interface{}{"sku": "e"}}}}, resp)
+	if resp.Code != 0 {
+		t.Fatalf("unexpected batch update %d %s %v", resp.Code, resp.Message, resp.Failures)
+	}
+	if lines(1) != "[e]" || lines(2) != "[e]" {
+		t.Fatalf("unexpected lines after batch update %s %s", lines(1), lines(2))
+	}
+
+	// 删除记录时同时删除子表记录
+	if err = Delete(op, "bill", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if lines(1) != "[]" || lines(2) != "[e]" {
+		t.Fatalf("unexpected lines after delete %s %s", lines(1), lines(2))
+	}
+}
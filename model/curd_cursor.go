@@ -0,0 +1,151 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("cursor无效")
+
+// pageCursor 游标分页的位置,由排序字段和id组成,编码后作为nextCursor返回给客户端
+type pageCursor struct {
+	Field string      `json:"f"`
+	Desc  bool        `json:"d"`
+	Value interface{} `json:"v"`
+	ID    interface{} `json:"id"`
+}
+
+func encodeCursor(c *pageCursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(s string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &pageCursor{}
+	// 使用UseNumber避免雪花ID之类的大整数丢失精度
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(c); err != nil || c.ID == nil {
+		return nil, ErrInvalidCursor
+	}
+	c.ID, c.Value = cursorValue(c.ID), cursorValue(c.Value)
+	return c, nil
+}
+
+func cursorValue(value interface{}) interface{} {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// cursorPage 游标分页的查询条件
+type cursorPage struct {
+	field  *MetadataField
+	column string
+	desc   bool
+}
+
+// newCursorPage 根据请求的排序字段创建游标分页,没有排序字段时使用默认的排序字段。
+// cursor中包含排序字段的原始值,所以不能使用加密字段和当前用户不能查看明文的脱敏字段排序
+func newCursorPage(op *Operator, md *Metadata, orderField string, desc bool, defaultColumn string, defaultDesc bool) (*cursorPage, error) {
+	if orderField == "" {
+		return &cursorPage{field: md.FieldByName(defaultColumn), column: defaultColumn, desc: defaultDesc}, nil
+	}
+	field := md.FieldByName(orderField)
+	if field == nil {
+		return nil, errors.New("不存在排序字段:" + orderField)
+	}
+	if field.Encrypt || (field.Mask != "" && !canUnmask(op, field)) {
+		return nil, errors.New("游标分页不支持使用加密或者脱敏字段排序:" + orderField)
+	}
+	return &cursorPage{field: field, column: LowerSnakeCase(field.Name), desc: desc}, nil
+}
+
+// where 返回cursor之后的记录,排序字段相同时使用id排序,
+// MySQL和SQLite中NULL排在最前面,Postgres中NULL排在最后面
+func (p *cursorPage) where(db *gorm.DB, cursor string) (*gorm.DB, error) {
+	if cursor == "" {
+		return db, nil
+	}
+	c, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if c.Field != p.column || c.Desc != p.desc {
+		return nil, errors.New("cursor和排序字段不一致")
+	}
+	cmp := " > ?"
+	if p.desc {
+		cmp = " < ?"
+	}
+	id := QuoteColumn("id")
+	if p.column == "id" {
+		return db.Where(id+cmp, c.ID), nil
+	}
+
+	column := QuoteColumn(p.column)
+	nullsLast := dbClient.DB().Dialector.Name() == "postgres"
+	nullsAfter := nullsLast != p.desc
+	if c.Value == nil {
+		if nullsAfter {
+			return db.Where(column+" IS NULL AND "+id+cmp, c.ID), nil
+		}
+		return db.Where("(("+column+" IS NULL AND "+id+cmp+") OR "+column+" IS NOT NULL)", c.ID), nil
+	}
+	value := c.Value
+	if p.field != nil {
+		if v, err := ConvertFieldValue(p.field, value); err == nil {
+			value = v
+		}
+	}
+	condition := column + cmp + " OR (" + column + " = ? AND " + id + cmp + ")"
+	if nullsAfter {
+		condition += " OR " + column + " IS NULL"
+	}
+	return db.Where("("+condition+")", value, value, c.ID), nil
+}
+
+func (p *cursorPage) order() string {
+	order := orderClause(p.column, p.desc)
+	if p.column != "id" {
+		order += "," + orderClause("id", p.desc)
+	}
+	return order
+}
+
+// next 查询结果比pageSize多一条时说明还有下一页,返回最后一条记录的位置
+func (p *cursorPage) next(rows []map[string]interface{}, pageSize int) ([]map[string]interface{}, string, error) {
+	if len(rows) <= pageSize {
+		return rows, "", nil
+	}
+	rows = rows[:pageSize]
+	last := rows[pageSize-1]
+	c := &pageCursor{Field: p.column, Desc: p.desc, ID: derefValue(last["id"])}
+	if p.column != "id" {
+		c.Value = derefValue(last[p.column])
+		if b, ok := c.Value.([]byte); ok {
+			c.Value = string(b)
+		}
+	}
+	next, err := encodeCursor(c)
+	return rows, next, err
+}
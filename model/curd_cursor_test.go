@@ -0,0 +1,104 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+	pkgmodel "github.com/CloudSilk/pkg/model"
+)
+
+func TestCursor(t *testing.T) {
+	s, err := encodeCursor(&pageCursor{Field: "created_at", Desc: true, Value: "2023-01-02 10:00:00", ID: int64(1234567890123456789)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := decodeCursor(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Field != "created_at" || !c.Desc || c.Value != "2023-01-02 10:00:00" || c.ID != int64(1234567890123456789) {
+		t.Fatalf("unexpected cursor %+v", c)
+	}
+	for _, s := range []string{"!!", "e30"} {
+		if _, err = decodeCursor(s); err != ErrInvalidCursor {
+			t.Errorf("%s: expected ErrInvalidCursor, got %v", s, err)
+		}
+	}
+}
+
+func TestCursorNext(t *testing.T) {
+	p := &cursorPage{column: "amount"}
+	rows := []map[string]interface{}{{"id": int64(1), "amount": int64(5)}, {"id": int64(2), "amount": int64(6)}}
+	if result, next, _ := p.next(rows, 2); len(result) != 2 || next != "" {
+		t.Fatalf("expected no next page, got %d %s", len(result), next)
+	}
+	result, next, err := p.next(rows, 1)
+	if err != nil || len(result) != 1 || next == "" {
+		t.Fatalf("expected next page, got %d %s %v", len(result), next, err)
+	}
+	c, _ := decodeCursor(next)
+	if c.Value != int64(5) || c.ID != int64(1) {
+		t.Fatalf("unexpected cursor %+v", c)
+	}
+}
+
+func TestNewCursorPage(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{
+		{Name: "id"}, {Name: "amount"}, {Name: "phone", Mask: "phone", UnmaskRoles: "admin"}, {Name: "idCard", Encrypt: true},
+	}}
+	staff, admin := &Operator{RoleIDs: []string{"staff"}}, &Operator{RoleIDs: []string{"admin"}}
+	for _, c := range []struct {
+		op    *Operator
+		field string
+		valid bool
+	}{
+		{staff, "", true},
+		{staff, "amount", true},
+		{staff, "phone", false},
+		{admin, "phone", true},
+		{admin, "idCard", false},
+		{admin, "unknown", false},
+	} {
+		if _, err := newCursorPage(c.op, md, c.field, false, "id", false); (err == nil) != c.valid {
+			t.Errorf("%s: unexpected result %v", c.field, err)
+		}
+	}
+}
+
+func TestCursorQueryTotal(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "cursor.db"), false), true)
+
+	md := &Metadata{Name: "CursorNote", MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}}}
+	if err := dbClient.DB().Create(&Page{Name: "cursor_note", Title: "笔记", Enable: true, Metadata: md}).Error; err != nil {
+		t.Fatal(err)
+	}
+	dbClient.DB().Exec("CREATE TABLE cursor_notes(id integer primary key autoincrement, name varchar(20))")
+	dbClient.DB().Exec("INSERT INTO cursor_notes(name) VALUES ('a'), ('b'), ('c'), ('d'), ('e')")
+
+	var names []interface{}
+	cursor := ""
+	for i := 0; i < 5; i++ {
+		resp := &QueryResponse{}
+		Query(&QueryRequest{PageName: "cursor_note", UseCursor: true, Cursor: cursor, Operator: SystemOperator(),
+			CommonRequest: pkgmodel.CommonRequest{PageInfo: pkgmodel.PageInfo{PageSize: 2}}}, resp)
+		if resp.Code != 0 {
+			t.Fatal(resp.Message)
+		}
+		// 每一页的Total都是所有记录数,不受游标影响
+		if resp.Total != 5 {
+			t.Fatalf("page %d: expected total 5, got %d", i+1, resp.Total)
+		}
+		for _, d := range resp.Data {
+			names = append(names, d["name"])
+		}
+		if cursor = resp.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if len(names) != 5 {
+		t.Fatalf("unexpected cursor result %v", names)
+	}
+}
@@ -0,0 +1,323 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+var ErrNoDataPermission = errors.New("没有数据权限")
+
+const (
+	DataRuleCurrentUser       = "currentUser"
+	dataRuleCurrentUserPrefix = "currentUser."
+)
+
+// dataRuleOperators 支持的操作符,较长的操作符需要放在前面
+var dataRuleOperators = []string{"is not null", "is null", "not in", "in", "like", ">=", "<=", "!=", "<>", "=", ">", "<"}
+
+// UserAttributeFunc 返回规则中currentUser.<属性>的值,例如部门列表
+type UserAttributeFunc func(op *Operator) (interface{}, error)
+
+var (
+	userAttributeLock sync.RWMutex
+	userAttributes    = map[string]UserAttributeFunc{
+		"id":       func(op *Operator) (interface{}, error) { return op.UserID, nil },
+		"userName": func(op *Operator) (interface{}, error) { return op.UserName, nil },
+		"tenantID": func(op *Operator) (interface{}, error) { return op.TenantID, nil },
+		"roleIDs":  func(op *Operator) (interface{}, error) { return op.RoleIDs, nil },
+		"group":    func(op *Operator) (interface{}, error) { return op.Group, nil },
+	}
+)
+
+// RegisterUserAttribute 注册数据权限规则中可以使用的用户属性,例如currentUser.depts,
+// 属性的值可以是单个值或者数组,数组用于in和not in
+func RegisterUserAttribute(name string, fn UserAttributeFunc) {
+	userAttributeLock.Lock()
+	defer userAttributeLock.Unlock()
+	userAttributes[name] = fn
+}
+
+func userAttribute(op *Operator, name string) (interface{}, error) {
+	userAttributeLock.RLock()
+	fn, ok := userAttributes[name]
+	userAttributeLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("数据权限规则不支持用户属性:%s", name)
+	}
+	return fn(op)
+}
+
+// dataRule 解析后的规则,格式为<字段> <操作符> <值>
+type dataRule struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// parseDataRule 解析规则,值可以是currentUser、currentUser.<属性>、带引号的字符串、数字、
+// 括号中使用逗号隔开的列表,其他没有引号的值当作字符串
+func parseDataRule(rule string) (*dataRule, error) {
+	rule = strings.TrimSpace(rule)
+	i := strings.IndexFunc(rule, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	if i <= 0 {
+		return nil, fmt.Errorf("数据权限规则%s格式错误", rule)
+	}
+	field, rest := rule[:i], strings.TrimSpace(rule[i:])
+	lower := strings.ToLower(rest)
+	for _, operator := range dataRuleOperators {
+		if !strings.HasPrefix(lower, operator) {
+			continue
+		}
+		// 单词形式的操作符后面必须是空格或者括号
+		if unicode.IsLetter(rune(operator[0])) && len(rest) > len(operator) && rest[len(operator)] != ' ' && rest[len(operator)] != '(' {
+			continue
+		}
+		value := strings.TrimSpace(rest[len(operator):])
+		if strings.HasSuffix(operator, "null") != (value == "") {
+			return nil, fmt.Errorf("数据权限规则%s格式错误", rule)
+		}
+		if operator == "<>" {
+			operator = "!="
+		}
+		return &dataRule{Field: field, Operator: operator, Value: value}, nil
+	}
+	return nil, fmt.Errorf("数据权限规则%s不支持的操作符", rule)
+}
+
+// sql 把规则转换成查询条件,字段不存在时返回错误
+func (r *dataRule) sql(op *Operator, md *Metadata) (string, []interface{}, error) {
+	field := md.FieldByName(r.Field)
+	if field == nil {
+		return "", nil, fmt.Errorf("数据权限规则的字段%s不存在", r.Field)
+	}
+	column := QuoteColumn(LowerSnakeCase(field.Name))
+	switch r.Operator {
+	case "is null", "is not null":
+		return column + " " + strings.ToUpper(r.Operator), nil, nil
+	case "in", "not in":
+		values, err := r.listValue(op)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(values) == 0 {
+			if r.Operator == "in" {
+				return "1 = 0", nil, nil
+			}
+			return "1 = 1", nil, nil
+		}
+		return column + " " + strings.ToUpper(r.Operator) + " ?", []interface{}{values}, nil
+	}
+	value, err := dataRuleValue(op, r.Value)
+	if err != nil {
+		return "", nil, err
+	}
+	if value != nil && reflect.TypeOf(value).Kind() == reflect.Slice {
+		return "", nil, fmt.Errorf("数据权限规则%s %s的值不能是数组", r.Field, r.Operator)
+	}
+	return column + " " + strings.ToUpper(r.Operator) + " ?", []interface{}{value}, nil
+}
+
+func (r *dataRule) listValue(op *Operator) ([]interface{}, error) {
+	if strings.HasPrefix(r.Value, "(") && strings.HasSuffix(r.Value, ")") {
+		var values []interface{}
+		for _, item := range strings.Split(r.Value[1:len(r.Value)-1], ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			value, err := dataRuleValue(op, item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	value, err := dataRuleValue(op, r.Value)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return []interface{}{value}, nil
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, nil
+}
+
+func dataRuleValue(op *Operator, value string) (interface{}, error) {
+	if value == DataRuleCurrentUser {
+		return op.UserID, nil
+	}
+	if strings.HasPrefix(value, dataRuleCurrentUserPrefix) {
+		return userAttribute(op, strings.TrimPrefix(value, dataRuleCurrentUserPrefix))
+	}
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1], nil
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	return value, nil
+}
+
+// CheckPageDataRules 保存页面配置时检查规则的格式
+func CheckPageDataRules(rules []*PageDataRule) error {
+	for _, rule := range rules {
+		if _, err := parseDataRule(rule.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataRuleSQL 返回当前用户在页面上的数据权限条件,没有限制时返回空字符串。
+// 角色为空的规则对所有用户生效;用户的某个角色没有配置规则时可以访问所有数据,
+// 否则用户可以访问任意一个角色允许的数据,没有任何角色时不能访问按角色配置了规则的数据。
+// 页面配置了规则时op为nil不能访问任何数据,系统内部调用需要使用SystemOperator
+func dataRuleSQL(op *Operator, page *Page) (string, []interface{}, error) {
+	if len(page.DataRules) == 0 || (op != nil && op.system) {
+		return "", nil, nil
+	}
+	var global []*PageDataRule
+	roles := make(map[string][]*PageDataRule)
+	for _, rule := range page.DataRules {
+		if !rule.Enable {
+			continue
+		}
+		if rule.RoleID == "" {
+			global = append(global, rule)
+		} else {
+			roles[rule.RoleID] = append(roles[rule.RoleID], rule)
+		}
+	}
+
+	if len(global) == 0 && len(roles) == 0 {
+		return "", nil, nil
+	}
+	if op == nil {
+		return "1 = 0", nil, nil
+	}
+
+	var conditions []string
+	var args []interface{}
+	sql, values, err := dataRulesSQL(op, page.Metadata, global)
+	if err != nil {
+		return "", nil, err
+	}
+	if sql != "" {
+		conditions = append(conditions, sql)
+		args = append(args, values...)
+	}
+	if len(roles) > 0 {
+		var roleConditions []string
+		var roleArgs []interface{}
+		unrestricted := false
+		for _, roleID := range op.RoleIDs {
+			if len(roles[roleID]) == 0 {
+				unrestricted = true
+				break
+			}
+			sql, values, err := dataRulesSQL(op, page.Metadata, roles[roleID])
+			if err != nil {
+				return "", nil, err
+			}
+			roleConditions = append(roleConditions, sql)
+			roleArgs = append(roleArgs, values...)
+		}
+		if len(roleConditions) == 0 {
+			conditions = append(conditions, "1 = 0")
+		} else if !unrestricted {
+			conditions = append(conditions, "("+strings.Join(roleConditions, " OR ")+")")
+			args = append(args, roleArgs...)
+		}
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// dataRulesSQL 同一组规则之间是AND关系
+func dataRulesSQL(op *Operator, md *Metadata, rules []*PageDataRule) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+	for _, rule := range rules {
+		r, err := parseDataRule(rule.Rule)
+		if err != nil {
+			return "", nil, err
+		}
+		sql, values, err := r.sql(op, md)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, sql)
+		args = append(args, values...)
+	}
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", args, nil
+}
+
+// ScopeDataRules 给查询加上当前用户的数据权限条件,规则错误时查询会返回错误
+func ScopeDataRules(db *gorm.DB, op *Operator, page *Page) *gorm.DB {
+	sql, args, err := dataRuleSQL(op, page)
+	if err != nil {
+		db.AddError(err)
+		return db
+	}
+	if sql == "" {
+		return db
+	}
+	return db.Where(sql, args...)
+}
+
+// metadataRecordDB 没有页面时按照所有使用这个元数据的页面的数据权限过滤,例如展开引用的记录,
+// 每个页面的规则都需要满足
+func metadataRecordDB(tx *gorm.DB, op *Operator, md *Metadata) *gorm.DB {
+	db := metadataDB(tx, op, md)
+	pages, err := metadataPages(md)
+	if err != nil {
+		db.AddError(err)
+		return db
+	}
+	for _, page := range pages {
+		db = ScopeDataRules(db, op, page)
+	}
+	return db
+}
+
+// metadataPages 返回所有使用这个元数据的启用的页面,包含数据权限和字段权限
+func metadataPages(md *Metadata) ([]*Page, error) {
+	var pages []*Page
+	err := dbClient.DB().Preload("DataRules").Preload("FieldPermissions").Where("metadata_id = ? and enable = ?", md.ID, true).Find(&pages).Error
+	for _, page := range pages {
+		page.Metadata = md
+	}
+	return pages, err
+}
+
+// checkDataPermission 新增和修改后检查记录是否仍然在当前用户的数据权限范围内,
+// 避免用户写入自己无权访问的数据
+func checkDataPermission(tx *gorm.DB, op *Operator, page *Page, id interface{}) error {
+	sql, _, err := dataRuleSQL(op, page)
+	if err != nil || sql == "" || id == nil {
+		return err
+	}
+	if err = existsByID(tx, op, page, id); errors.Is(err, ErrRecordNotExist) {
+		return ErrNoDataPermission
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+package model
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	apipb "github.com/CloudSilk/curd/proto"
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestParseDataRule(t *testing.T) {
+	for _, c := range []struct {
+		rule string
+		want *dataRule
+	}{
+		{"owner = currentUser", &dataRule{"owner", "=", "currentUser"}},
+		{"dept in currentUser.depts", &dataRule{"dept", "in", "currentUser.depts"}},
+		{"status <> archived", &dataRule{"status", "!=", "archived"}},
+		{"level>=2", &dataRule{"level", ">=", "2"}},
+		{"status NOT IN ('a', 'b')", &dataRule{"status", "not in", "('a', 'b')"}},
+		{"closedAt is null", &dataRule{"closedAt", "is null", ""}},
+		{"index = 1", &dataRule{"index", "=", "1"}},
+	} {
+		got, err := parseDataRule(c.rule)
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: expected %+v, got %+v %v", c.rule, c.want, got, err)
+		}
+	}
+	for _, rule := range []string{"", "= 1", "owner", "owner ~ 1", "owner = ", "status is null 1", "status inx (1)"} {
+		if _, err := parseDataRule(rule); err == nil {
+			t.Errorf("%s: expected error", rule)
+		}
+	}
+}
+
+func TestDataRuleSQL(t *testing.T) {
+	RegisterUserAttribute("depts", func(op *Operator) (interface{}, error) {
+		if op.UserID == "u1" {
+			return []string{"d1", "d2"}, nil
+		}
+		return nil, nil
+	})
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "owner"}, {Name: "dept"}, {Name: "status"}}}
+	page := &Page{Metadata: md, DataRules: []*PageDataRule{
+		{Rule: "status != archived", Enable: true},
+		{RoleID: "sales", Rule: "owner = currentUser", Enable: true},
+		{RoleID: "manager", Rule: "dept in currentUser.depts", Enable: true},
+		{RoleID: "manager", Rule: "status in (1, 'open')", Enable: true},
+		{RoleID: "manager", Rule: "owner = nobody", Enable: false},
+	}}
+	owner, dept, status := QuoteColumn("owner"), QuoteColumn("dept"), QuoteColumn("status")
+	for _, c := range []struct {
+		op   *Operator
+		sql  string
+		args []interface{}
+	}{
+		{nil, "1 = 0", nil},
+		{SystemOperator(), "", nil},
+		// 没有角色时只能访问所有用户都可以访问的数据
+		{&Operator{UserID: "u1"}, "(" + status + " != ?) AND 1 = 0", []interface{}{"archived"}},
+		{&Operator{UserID: "u1", RoleIDs: []string{"sales"}}, "(" + status + " != ?) AND ((" + owner + " = ?))", []interface{}{"archived", "u1"}},
+		{&Operator{UserID: "u1", RoleIDs: []string{"sales", "manager"}},
+			"(" + status + " != ?) AND ((" + owner + " = ?) OR (" + dept + " IN ? AND " + status + " IN ?))",
+			[]interface{}{"archived", "u1", []interface{}{"d1", "d2"}, []interface{}{int64(1), "open"}}},
+		{&Operator{UserID: "u2", RoleIDs: []string{"manager"}}, "(" + status + " != ?) AND ((1 = 0 AND " + status + " IN ?))", []interface{}{"archived", []interface{}{int64(1), "open"}}},
+		// 没有配置规则的角色可以访问所有数据
+		{&Operator{UserID: "u1", RoleIDs: []string{"sales", "admin"}}, "(" + status + " != ?)", []interface{}{"archived"}},
+	} {
+		sql, args, err := dataRuleSQL(c.op, page)
+		if err != nil || sql != c.sql || !reflect.DeepEqual(args, c.args) {
+			t.Errorf("%+v: expected %s %v, got %s %v %v", c.op, c.sql, c.args, sql, args, err)
+		}
+	}
+
+	page.DataRules = append(page.DataRules, &PageDataRule{Rule: "owner = currentUser.phone", Enable: true})
+	if _, _, err := dataRuleSQL(&Operator{}, page); err == nil {
+		t.Fatal("expected error for unknown user attribute")
+	}
+}
+
+func TestDataRuleScope(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "datarule.db"), false), true)
+
+	md := &Metadata{Name: "RuleTask", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar", Unique: true}, {Name: "owner", Type: "varchar"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "rule_task", Title: "任务", Enable: true, EnableAudit: true, Metadata: md, DataRules: []*PageDataRule{
+		{RoleID: "member", Rule: "owner = currentUser", Enable: true},
+	}}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE rule_tasks(id integer primary key autoincrement, name varchar(20), owner varchar(36))").Error; err != nil {
+		t.Fatal(err)
+	}
+	alice := &Operator{UserID: "alice", RoleIDs: []string{"member"}}
+	bob := &Operator{UserID: "bob", RoleIDs: []string{"member"}}
+	for _, m := range []map[string]interface{}{{"name": "a1", "owner": "alice"}, {"name": "b1", "owner": "bob"}} {
+		if err = Create(SystemOperator(), "rule_task", m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list, err := GetAll(alice, "rule_task")
+	if err != nil || len(list) != 1 || list[0]["name"] != "a1" {
+		t.Fatalf("unexpected records %v %v", list, err)
+	}
+	if data, err := GetDetailById(alice, "rule_task", "2", "", ""); err != nil || len(data) != 0 {
+		t.Fatalf("unexpected detail %v %v", data, err)
+	}
+	if err = Patch(alice, "rule_task", map[string]interface{}{"id": 2, "name": "b2"}); !errors.Is(err, ErrRecordNotExist) {
+		t.Fatalf("expected ErrRecordNotExist, got %v", err)
+	}
+	if err = Delete(alice, "rule_task", "2"); !errors.Is(err, ErrRecordNotExist) {
+		t.Fatalf("expected ErrRecordNotExist, got %v", err)
+	}
+	// 不能把数据改成或者新增成自己没有权限的数据
+	if err = Patch(alice, "rule_task", map[string]interface{}{"id": 1, "owner": "bob"}); !errors.Is(err, ErrNoDataPermission) {
+		t.Fatalf("expected ErrNoDataPermission, got %v", err)
+	}
+	if err = Create(alice, "rule_task", map[string]interface{}{"name": "a2", "owner": "bob"}); !errors.Is(err, ErrNoDataPermission) {
+		t.Fatalf("expected ErrNoDataPermission, got %v", err)
+	}
+	// 唯一字段需要和没有权限的数据比较
+	if err = Create(alice, "rule_task", map[string]interface{}{"name": "b1", "owner": "alice"}); err == nil {
+		t.Fatal("expected duplication error")
+	}
+	// 没有用户信息时不能访问配置了数据权限的数据
+	if list, err = GetAll(nil, "rule_task"); err != nil || len(list) != 0 {
+		t.Fatalf("unexpected records %v %v", list, err)
+	}
+	history := &HistoryResponse{}
+	History(&HistoryRequest{PageName: "rule_task", ID: "2", Operator: alice}, history)
+	if history.Code != int(apipb.Code_NoPermission) {
+		t.Fatalf("expected no permission, got %d %s", history.Code, history.Message)
+	}
+	history = &HistoryResponse{}
+	History(&HistoryRequest{PageName: "rule_task", ID: "1", Operator: alice}, history)
+	if history.Code != 0 || len(history.Data) != 1 {
+		t.Fatalf("unexpected history %d %s %v", history.Code, history.Message, history.Data)
+	}
+	// 展开引用的记录时按照被引用页面的数据权限过滤
+	cmd := &Metadata{Name: "RuleComment", MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}, {Name: "task", Type: "bigint", RefMetadata: md.ID}}}
+	if err = dbClient.DB().Create(&Page{Name: "rule_comment", Title: "评论", Enable: true, Metadata: cmd}).Error; err != nil {
+		t.Fatal(err)
+	}
+	dbClient.DB().Exec("CREATE TABLE rule_comments(id integer primary key autoincrement, task bigint)")
+	dbClient.DB().Exec("INSERT INTO rule_comments(task) VALUES (1), (2)")
+	for id, want := range map[string]bool{"1": true, "2": false} {
+		data, err := GetDetailById(alice, "rule_comment", id, "task", "")
+		if err != nil || (data["task"] != nil) != want {
+			t.Fatalf("comment %s: unexpected detail %v %v", id, data, err)
+		}
+	}
+
+	resp := &QueryResponse{}
+	Query(&QueryRequest{PageName: "rule_task", Operator: bob}, resp)
+	if resp.Code != 0 || resp.Total != 1 || resp.Data[0]["name"] != "b1" {
+		t.Fatalf("unexpected query %d %s %v", resp.Code, resp.Message, resp.Data)
+	}
+	if err = Delete(bob, "rule_task", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	page, _ := GetPageByName("rule_task")
+	page.DataRules[0].Rule = "manager = currentUser"
+	if err = dbClient.DB().Save(page.DataRules[0]).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err = GetAll(alice, "rule_task"); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
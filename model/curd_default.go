@@ -0,0 +1,97 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operator 当前操作的用户信息
+type Operator struct {
+	UserID   string
+	UserName string
+	TenantID string
+	RoleIDs  []string
+	// Group usercenter中用户的分组
+	Group   string
+	TransID string
+	//平台租户为true时不按租户隔离数据
+	AllTenants bool
+	// system 系统内部调用,不受数据权限限制,只能通过SystemOperator创建
+	system bool
+}
+
+const (
+	DefaultValueNow         = "$now"
+	DefaultValueUUID        = "$uuid"
+	DefaultValueCurrentUser = "$currentUser"
+	DefaultValueTenantID    = "$tenantID"
+)
+
+// ApplyDefaultValues 给m中没有传值的字段设置默认值,先使用页面配置的新增默认值,再使用元数据字段的默认值
+func ApplyDefaultValues(op *Operator, page *Page, m map[string]interface{}) error {
+	md := page.Metadata
+	if page.AddDefaultValue != "" {
+		defaults := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(page.AddDefaultValue), &defaults); err != nil {
+			return err
+		}
+		for key, value := range defaults {
+			field := md.FieldByName(key)
+			if field == nil || m[field.Name] != nil {
+				continue
+			}
+			m[field.Name] = resolveDefaultValue(op, value)
+		}
+	}
+	for _, field := range md.MetadataFields {
+		if m[field.Name] != nil || autoFields[LowerSnakeCase(field.Name)] {
+			continue
+		}
+		if value, ok := metadataDefaultValue(field.DefaultValue); ok {
+			m[field.Name] = resolveDefaultValue(op, value)
+		}
+	}
+	return nil
+}
+
+// metadataDefaultValue 元数据的默认值同时用于生成建表语句,需要去掉SQL的引号和NULL
+func metadataDefaultValue(value string) (interface{}, bool) {
+	value = strings.TrimSpace(value)
+	switch strings.ToUpper(value) {
+	case "", "NULL":
+		return nil, false
+	case "CURRENT_TIMESTAMP", "CURRENT_TIMESTAMP(3)", "NOW()":
+		return DefaultValueNow, true
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = value[1 : len(value)-1]
+	}
+	return value, true
+}
+
+func resolveDefaultValue(op *Operator, value interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+	switch str {
+	case DefaultValueNow:
+		return time.Now()
+	case DefaultValueUUID:
+		return uuid.New().String()
+	case DefaultValueCurrentUser:
+		if op == nil {
+			return nil
+		}
+		return op.UserID
+	case DefaultValueTenantID:
+		if op == nil {
+			return nil
+		}
+		return op.TenantID
+	}
+	return value
+}
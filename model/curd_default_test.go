@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyDefaultValues(t *testing.T) {
+	page := &Page{
+		AddDefaultValue: `{"status":"draft","creator":"$currentUser"}`,
+		Metadata: &Metadata{
+			MetadataFields: []*MetadataField{
+				{Name: "id", DefaultValue: "$uuid"},
+				{Name: "status", DefaultValue: "'new'"},
+				{Name: "age", DefaultValue: "18"},
+				{Name: "code", DefaultValue: "$uuid"},
+				{Name: "creator"},
+				{Name: "tenantID", DefaultValue: "$tenantID"},
+				{Name: "orderTime", DefaultValue: "CURRENT_TIMESTAMP"},
+				{Name: "remark", DefaultValue: "NULL"},
+				{Name: "name", DefaultValue: "'x'"},
+			},
+		},
+	}
+	m := map[string]interface{}{"name": "tom"}
+	err := ApplyDefaultValues(&Operator{UserID: "u1", TenantID: "t1"}, page, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["status"] != "draft" || m["creator"] != "u1" || m["tenantID"] != "t1" || m["age"] != "18" || m["name"] != "tom" {
+		t.Fatalf("unexpected defaults %v", m)
+	}
+	if _, ok := m["orderTime"].(time.Time); !ok {
+		t.Fatalf("expected orderTime to be time, got %v", m["orderTime"])
+	}
+	if code, _ := m["code"].(string); len(code) != 36 {
+		t.Fatalf("expected uuid, got %v", m["code"])
+	}
+	if _, ok := m["remark"]; ok {
+		t.Fatal("NULL default should be skipped")
+	}
+	if _, ok := m["id"]; ok {
+		t.Fatal("id should not use default value")
+	}
+}
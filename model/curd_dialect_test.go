@@ -0,0 +1,239 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CloudSilk/pkg/db"
+	pgclient "github.com/CloudSilk/pkg/db/postgres"
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+	pkgmodel "github.com/CloudSilk/pkg/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestInsertStatement(t *testing.T) {
+	for _, c := range []struct {
+		dialector gorm.Dialector
+		sql       string
+	}{
+		{sqlite.Open("file::memory:"), "INSERT INTO `orders` (`name`,`tenant_id`) VALUES (?,?)"},
+		{postgres.Open("host=localhost"), `INSERT INTO "orders" ("name","tenant_id") VALUES ($1,$2) RETURNING "id"`},
+	} {
+		tx, err := gorm.Open(c.dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stmt, _ := insertStatement(tx, "orders", []string{"name", "tenant_id"}, []interface{}{"a", "t"})
+		if sql := stmt.SQL.String(); sql != c.sql {
+			t.Errorf("%s: expected %s, got %s", tx.Dialector.Name(), c.sql, sql)
+		}
+	}
+}
+
+// 同样的场景分别在SQLite和Postgres上运行,Postgres需要通过CURD_TEST_POSTGRES指定连接字符串
+func TestCurdSqlite(t *testing.T) {
+	runDialectScenarios(t, sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "curd.db"), false))
+}
+
+func TestCurdPostgres(t *testing.T) {
+	dsn := os.Getenv("CURD_TEST_POSTGRES")
+	if dsn == "" {
+		t.Skip("没有设置CURD_TEST_POSTGRES")
+	}
+	runDialectScenarios(t, pgclient.NewPostgres(dsn, false))
+}
+
+type dialectOrder struct {
+	ID        int64  `gorm:"primaryKey;autoIncrement"`
+	Name      string `gorm:"size:50"`
+	Age       int
+	Amount    float64
+	Enable    bool
+	ParentID  int64
+	Level     int
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+func runDialectScenarios(t *testing.T, client db.DBClientInterface) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(client, true)
+
+	md := &Metadata{Name: "DialectOrder", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"},
+		{Name: "name", Type: "varchar", Length: 50, Unique: true, Like: true, ShowInQuery: true},
+		{Name: "age", Type: "int", ShowInQuery: true},
+		{Name: "amount", Type: "decimal"},
+		{Name: "enable", Type: "bool"},
+		{Name: "parentID", Type: "bigint"},
+		{Name: "level", Type: "int"},
+		{Name: "version", Type: "int"},
+		{Name: "createdAt", Type: "datetime"},
+		{Name: "updatedAt", Type: "datetime"},
+		{Name: "deletedAt", Type: "datetime"},
+	}}
+	table := NamingStrategy.TableName(md.Name)
+	tx := dbClient.DB()
+	tx.Unscoped().Where("name = ?", "dialect_order").Delete(&Page{})
+	tx.Migrator().DropTable(table)
+	if err := tx.Table(table).AutoMigrate(&dialectOrder{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Create(&Page{Name: "dialect_order", Title: "订单", Enable: true, Metadata: md}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	records := []map[string]interface{}{
+		{"name": "apple", "age": 10, "amount": 1.5, "enable": true},
+		{"name": "banana", "age": 20, "amount": 2.5, "enable": true},
+		{"name": "cherry", "age": 30, "amount": 3.5, "enable": false},
+	}
+	for _, m := range records {
+		if err := Create(nil, "dialect_order", m); err != nil {
+			t.Fatal(err)
+		}
+		if m["id"] == nil {
+			t.Fatal("新增后没有返回id")
+		}
+	}
+	ids := []string{treeKey(records[0]["id"]), treeKey(records[1]["id"]), treeKey(records[2]["id"])}
+	if err := Create(nil, "dialect_order", map[string]interface{}{"name": "apple"}); err == nil {
+		t.Fatal("重复的name应该新增失败")
+	}
+
+	t.Run("query", func(t *testing.T) {
+		resp := &QueryResponse{}
+		Query(&QueryRequest{PageName: "dialect_order", Data: map[string]interface{}{"age__gte": 20}, Keyword: "an",
+			CommonRequest: pkgmodel.CommonRequest{PageInfo: pkgmodel.PageInfo{PageSize: 10, OrderField: "age", Desc: true}}}, resp)
+		if resp.Code != 0 || resp.Total != 1 || resp.Data[0]["name"] != "banana" {
+			t.Fatalf("unexpected query result %d %s %v", resp.Code, resp.Message, resp.Data)
+		}
+		// 没有后缀时精确查询,like查询中的%和_按照普通字符匹配
+		for data, total := range map[string]int64{"name": 0, "name__like": 1} {
+			resp = &QueryResponse{}
+			Query(&QueryRequest{PageName: "dialect_order", Data: map[string]interface{}{data: "nan"}}, resp)
+			if resp.Code != 0 || resp.Total != total {
+				t.Fatalf("%s: unexpected query result %d %s %v", data, resp.Code, resp.Message, resp.Data)
+			}
+		}
+		for _, keyword := range []string{"%", "a_p"} {
+			resp = &QueryResponse{}
+			Query(&QueryRequest{PageName: "dialect_order", Data: map[string]interface{}{"name__like": keyword}}, resp)
+			if resp.Code != 0 || resp.Total != 0 {
+				t.Fatalf("%s: unexpected query result %d %s %v", keyword, resp.Code, resp.Message, resp.Data)
+			}
+		}
+
+		var names []interface{}
+		cursor := ""
+		for i := 0; i < 5; i++ {
+			resp = &QueryResponse{}
+			Query(&QueryRequest{PageName: "dialect_order", UseCursor: true, Cursor: cursor,
+				CommonRequest: pkgmodel.CommonRequest{PageInfo: pkgmodel.PageInfo{PageSize: 2, OrderField: "age", Desc: true}}}, resp)
+			if resp.Code != 0 {
+				t.Fatal(resp.Message)
+			}
+			for _, d := range resp.Data {
+				names = append(names, d["name"])
+			}
+			if cursor = resp.NextCursor; cursor == "" {
+				break
+			}
+		}
+		if len(names) != 3 || names[0] != "cherry" || names[2] != "apple" {
+			t.Fatalf("unexpected cursor result %v", names)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		if err := Patch(nil, "dialect_order", map[string]interface{}{"id": ids[0], "age": 11, "version": 0}); err != nil {
+			t.Fatal(err)
+		}
+		err := Patch(nil, "dialect_order", map[string]interface{}{"id": ids[0], "age": 12, "version": 0})
+		if !errors.Is(err, ErrRecordChanged) {
+			t.Fatalf("expected ErrRecordChanged, got %v", err)
+		}
+		data, err := GetDetailById(nil, "dialect_order", ids[0], "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if age, _ := toInt64(derefValue(data["age"])); age != int64(11) {
+			t.Fatalf("expected age 11, got %v", data["age"])
+		}
+		batch := &BatchResponse{}
+		BatchEnable(&BatchRequest{PageName: "dialect_order", IDs: []string{ids[2]}, Enable: true}, batch)
+		if batch.Code != 0 {
+			t.Fatal(batch.Message)
+		}
+	})
+
+	t.Run("aggregate", func(t *testing.T) {
+		resp := &AggregateResponse{}
+		Aggregate(&AggregateRequest{PageName: "dialect_order", GroupBy: "enable", Metrics: "count,sum:amount"}, resp)
+		if resp.Code != 0 || len(resp.Data) != 1 {
+			t.Fatalf("unexpected aggregate result %d %s %v", resp.Code, resp.Message, resp.Data)
+		}
+		if sum, _ := toFloat64(resp.Data[0]["sumAmount"]); sum != 7.5 {
+			t.Fatalf("expected sum 7.5, got %v", resp.Data[0])
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		if err := MoveTreeNode(&TreeMoveRequest{PageName: "dialect_order", ID: ids[1], ParentID: ids[0]}); err != nil {
+			t.Fatal(err)
+		}
+		if err := MoveTreeNode(&TreeMoveRequest{PageName: "dialect_order", ID: ids[2], ParentID: ids[1]}); err != nil {
+			t.Fatal(err)
+		}
+		if err := MoveTreeNode(&TreeMoveRequest{PageName: "dialect_order", ID: ids[0], ParentID: ids[2]}); err != ErrTreeCycle {
+			t.Fatalf("expected ErrTreeCycle, got %v", err)
+		}
+		path, err := TreePath(nil, "dialect_order", ids[2])
+		if err != nil || len(path) != 3 {
+			t.Fatalf("unexpected path %v %v", path, err)
+		}
+		if level, _ := toInt64(derefValue(path[2]["level"])); level != int64(2) {
+			t.Fatalf("expected level 2, got %v", path[2]["level"])
+		}
+		children, err := TreeChildren(nil, "dialect_order", "")
+		if err != nil || len(children) != 1 || children[0]["isLeaf"] != false {
+			t.Fatalf("unexpected children %v %v", children, err)
+		}
+	})
+
+	t.Run("trash", func(t *testing.T) {
+		if err := Delete(nil, "dialect_order", ids[2]); err != nil {
+			t.Fatal(err)
+		}
+		resp := &QueryResponse{}
+		Trash(&QueryRequest{PageName: "dialect_order"}, resp)
+		if resp.Code != 0 || resp.Total != 1 {
+			t.Fatalf("unexpected trash %d %s %v", resp.Code, resp.Message, resp.Data)
+		}
+		batch := &BatchResponse{}
+		Restore(&BatchRequest{PageName: "dialect_order", IDs: []string{ids[2]}}, batch)
+		if batch.Code != 0 {
+			t.Fatal(batch.Message)
+		}
+		if err := Delete(nil, "dialect_order", ids[2]); err != nil {
+			t.Fatal(err)
+		}
+		batch = &BatchResponse{}
+		Purge(&BatchRequest{PageName: "dialect_order", IDs: []string{ids[2]}}, batch)
+		if batch.Code != 0 {
+			t.Fatal(batch.Message)
+		}
+		list, err := GetAll(nil, "dialect_order")
+		if err != nil || len(list) != 2 {
+			t.Fatalf("unexpected records %v %v", list, err)
+		}
+	})
+}
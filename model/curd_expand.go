@@ -0,0 +1,254 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxExpandDepth expand最多允许展开的层级
+const MaxExpandDepth = 3
+
+const (
+	// ExpandModeObject 展开成引用的完整记录
+	ExpandModeObject = "object"
+	// ExpandModeLabel 展开成引用记录的LabelField
+	ExpandModeLabel = "label"
+)
+
+// defaultLabelField 页面字段没有配置LabelField时使用的显示字段
+const defaultLabelField = "name"
+
+// expandTree expand解析后的字段树,key是字段名
+type expandTree map[string]expandTree
+
+// parseExpand 解析expand参数,多个字段使用逗号隔开,使用.展开下一层,例如:role,dept.parent
+func parseExpand(expand string) (expandTree, error) {
+	tree := expandTree{}
+	for _, path := range strings.Split(expand, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		names := strings.Split(path, ".")
+		if len(names) > MaxExpandDepth {
+			return nil, fmt.Errorf("expand最多展开%d层:%s", MaxExpandDepth, path)
+		}
+		node := tree
+		for _, name := range names {
+			if name == "" {
+				return nil, fmt.Errorf("expand格式错误:%s", path)
+			}
+			child, ok := node[name]
+			if !ok {
+				child = expandTree{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return tree, nil
+}
+
+// ExpandRecords 把records中引用其他元数据(RefMetadata)的字段展开,
+// 非数组字段展开成引用的记录,数组字段展开成子表中的记录列表,
+// mode为label时最后一层只返回LabelField
+func ExpandRecords(op *Operator, page *Page, records []map[string]interface{}, expand, mode string) error {
+	if expand == "" || len(records) == 0 {
+		return nil
+	}
+	if mode == "" {
+		mode = ExpandModeObject
+	}
+	if mode != ExpandModeObject && mode != ExpandModeLabel {
+		return fmt.Errorf("不支持的expandMode:%s", mode)
+	}
+	tree, err := parseExpand(expand)
+	if err != nil {
+		return err
+	}
+	labelFields := make(map[string]string)
+	for _, field := range page.Fields {
+		if field.LabelField != "" {
+			labelFields[LowerSnakeCase(field.Name)] = field.LabelField
+		}
+	}
+	return expandRecords(op, page.Metadata, records, tree, labelFields, mode)
+}
+
+func expandRecords(op *Operator, md *Metadata, records []map[string]interface{}, tree expandTree, labelFields map[string]string, mode string) error {
+	for name, children := range tree {
+		field := md.FieldByName(name)
+		if field == nil {
+			return fmt.Errorf("不存在字段:%s", name)
+		}
+		if field.RefMetadata == "" {
+			return fmt.Errorf("字段%s没有引用其他元数据,不能展开", name)
+		}
+		refMD, err := GetMetadataById(field.RefMetadata)
+		if err != nil {
+			return err
+		}
+		labelField := labelFields[LowerSnakeCase(field.Name)]
+		if labelField == "" {
+			labelField = defaultLabelField
+		}
+		if field.IsArray {
+			err = expandChildren(op, md, field, refMD, records, children, labelField, mode)
+		} else {
+			err = expandRef(op, md, field, refMD, records, children, labelField, mode)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandRef 展开单个引用,引用的ID保存在<字段名>ID字段中,没有这个字段时保存在字段本身
+func expandRef(op *Operator, md *Metadata, field *MetadataField, refMD *Metadata, records []map[string]interface{}, children expandTree, labelField, mode string) error {
+	keyField := refKeyField(md, field)
+	key := CamelName2(LowerSnakeCase(keyField.Name))
+	var ids []interface{}
+	seen := make(map[string]bool)
+	for _, record := range records {
+		id := derefValue(record[key])
+		if isEmptyValue(id) || seen[fmt.Sprint(id)] {
+			continue
+		}
+		seen[fmt.Sprint(id)] = true
+		ids = append(ids, id)
+	}
+
+	refs := make(map[string]map[string]interface{})
+	if len(ids) > 0 {
+		rows, err := loadExpandRows(op, refMD, "id", ids, children, mode)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			refs[fmt.Sprint(derefValue(row["id"]))] = row
+		}
+		if err = stripExpandRows(op, refMD, rows); err != nil {
+			return err
+		}
+	}
+
+	out := CamelName2(LowerSnakeCase(field.Name))
+	for _, record := range records {
+		ref, ok := refs[fmt.Sprint(derefValue(record[key]))]
+		if !ok {
+			record[out] = nil
+			continue
+		}
+		record[out] = expandValue(ref, children, labelField, mode)
+	}
+	return nil
+}
+
+// expandChildren 展开子表,子表通过<父元数据名>ID字段关联到当前记录
+func expandChildren(op *Operator, md *Metadata, field *MetadataField, refMD *Metadata, records []map[string]interface{}, children expandTree, labelField, mode string) error {
+	fkField := childKeyField(md, refMD)
+	if fkField == nil {
+		return fmt.Errorf("子表%s没有关联字段%sID", refMD.Name, md.Name)
+	}
+	var ids []interface{}
+	for _, record := range records {
+		if id := derefValue(record["id"]); !isEmptyValue(id) {
+			ids = append(ids, id)
+		}
+	}
+
+	fk := LowerSnakeCase(fkField.Name)
+	groups := make(map[string][]interface{})
+	if len(ids) > 0 {
+		rows, err := loadExpandRows(op, refMD, fk, ids, children, mode)
+		if err != nil {
+			return err
+		}
+		key := CamelName2(fk)
+		parentIDs := make([]string, len(rows))
+		for i, row := range rows {
+			parentIDs[i] = fmt.Sprint(derefValue(row[key]))
+		}
+		// 关联字段也可能是隐藏的,先取出父记录的ID再去掉隐藏字段
+		if err = stripExpandRows(op, refMD, rows); err != nil {
+			return err
+		}
+		for i, row := range rows {
+			groups[parentIDs[i]] = append(groups[parentIDs[i]], expandValue(row, children, labelField, mode))
+		}
+	}
+
+	out := CamelName2(LowerSnakeCase(field.Name))
+	for _, record := range records {
+		list := groups[fmt.Sprint(derefValue(record["id"]))]
+		if list == nil {
+			list = []interface{}{}
+		}
+		record[out] = list
+	}
+	return nil
+}
+
+// loadExpandRows 批量加载当前用户有数据权限的引用记录,并继续展开下一层,
+// 调用方关联完记录后需要用stripHiddenFields去掉引用的页面中隐藏的字段
+func loadExpandRows(op *Operator, md *Metadata, column string, values []interface{}, children expandTree, mode string) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	err := metadataRecordDB(dbClient.DB(), op, md).Where(QuoteColumn(column)+" IN ?", values).Order(QuoteColumn("id")).Find(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]map[string]interface{}, len(result))
+	for i, data := range result {
+		row := make(map[string]interface{})
+		for key, value := range data {
+			row[CamelName2(key)] = value
+		}
+		rows[i] = row
+	}
+	if err = revealRecords(op, md, rows...); err != nil {
+		return nil, err
+	}
+	if len(children) > 0 {
+		if err = expandRecords(op, md, rows, children, nil, mode); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// stripExpandRows 去掉引用的元数据所在页面中隐藏的字段
+func stripExpandRows(op *Operator, md *Metadata, rows []map[string]interface{}) error {
+	permissions, err := metadataFieldPermissions(op, md)
+	if err != nil {
+		return err
+	}
+	stripHiddenFields(permissions, rows...)
+	return nil
+}
+
+func expandValue(row map[string]interface{}, children expandTree, labelField, mode string) interface{} {
+	if mode == ExpandModeLabel && len(children) == 0 {
+		return row[CamelName2(LowerSnakeCase(labelField))]
+	}
+	return row
+}
+
+// IsRelationField 判断字段是否是没有对应列的关联字段:
+// 数组引用的子表,或者引用ID保存在<字段名>ID中的引用
+func IsRelationField(md *Metadata, field *MetadataField) bool {
+	return field.RefMetadata != "" && (field.IsArray || refKeyField(md, field) != field)
+}
+
+// refKeyField 返回保存引用ID的字段,例如字段Role的引用ID保存在RoleID中
+func refKeyField(md *Metadata, field *MetadataField) *MetadataField {
+	if keyField := md.FieldByName(field.Name + "ID"); keyField != nil && keyField != field {
+		return keyField
+	}
+	return field
+}
+
+// childKeyField 返回子表中关联父记录的字段,例如Order的子表中的OrderID
+func childKeyField(parent, child *Metadata) *MetadataField {
+	return child.FieldByName(parent.Name + "ID")
+}
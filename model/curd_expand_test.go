@@ -0,0 +1,33 @@
+package model
+
+import "testing"
+
+func TestParseExpand(t *testing.T) {
+	tree, err := parseExpand("role, dept.parent,dept.manager,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 2 || len(tree["role"]) != 0 || len(tree["dept"]) != 2 {
+		t.Fatalf("unexpected tree %v", tree)
+	}
+	if _, err = parseExpand("a.b.c.d"); err == nil {
+		t.Fatal("expand deeper than MaxExpandDepth should fail")
+	}
+	if _, err = parseExpand("a..b"); err == nil {
+		t.Fatal("empty field name should fail")
+	}
+}
+
+func TestIsRelationField(t *testing.T) {
+	md := &Metadata{Name: "Order", MetadataFields: []*MetadataField{
+		{Name: "customerID", RefMetadata: "c"},
+		{Name: "customer", RefMetadata: "c"},
+		{Name: "owner", RefMetadata: "u"},
+		{Name: "lines", RefMetadata: "l", IsArray: true},
+	}}
+	for i, want := range []bool{false, true, false, true} {
+		if got := IsRelationField(md, md.MetadataFields[i]); got != want {
+			t.Errorf("%s: expected %v, got %v", md.MetadataFields[i].Name, want, got)
+		}
+	}
+}
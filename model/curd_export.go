@@ -0,0 +1,290 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportErrorMarker CSV已经开始输出后出错时,在最后一行写入这个前缀和错误信息,表示文件不完整
+const ExportErrorMarker = "#导出失败:"
+
+type exportColumn struct {
+	Title     string
+	Column    string
+	ValueEnum map[string]string
+	field     *MetadataField
+}
+
+// Exporter 按页面配置的字段导出查询结果,逐行读取数据库,不会一次性把数据加载到内存
+type Exporter struct {
+	Page    *Page
+	op      *Operator
+	columns []*exportColumn
+	db      *gorm.DB
+}
+
+func NewExporter(req *QueryRequest) (*Exporter, error) {
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		return nil, err
+	}
+	permissions := fieldPermissions(req.Operator, page)
+	md := readableMetadata(page.Metadata, permissions)
+	filters, err := ParseFilters(req.Operator, md, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	order, err := OrderByMetadata(req.Operator, md, req.OrderField, req.Desc, QuoteColumn("id"))
+	if err != nil {
+		return nil, err
+	}
+	db, err := ApplyKeyword(ApplyFilters(recordDB(dbClient.DB(), req.Operator, page), filters), req.Operator, md, req.Keyword)
+	if err != nil {
+		return nil, err
+	}
+	// 排序条件只添加一次,Session之后每次导出都从同一个查询开始
+	e := &Exporter{
+		Page: page,
+		op:   req.Operator,
+		db:   db.Order(order).Session(&gorm.Session{}),
+	}
+	for _, field := range page.Fields {
+		// 不导出没有权限查看的字段
+		if mdField := page.Metadata.FieldByName(field.Name); mdField != nil && permissions[mdField.Name] == FieldPermissionHidden {
+			continue
+		}
+		col := &exportColumn{
+			Title:     field.Title,
+			ValueEnum: ParseValueEnum(field.ValueEnum),
+		}
+		if col.Title == "" {
+			col.Title = field.Name
+		}
+		if mdField := page.Metadata.FieldByName(field.Name); mdField != nil {
+			col.Column = LowerSnakeCase(mdField.Name)
+			col.field = mdField
+		}
+		e.columns = append(e.columns, col)
+	}
+	return e, nil
+}
+
+func (e *Exporter) FileName(format string) string {
+	name := e.Page.Title
+	if name == "" {
+		name = e.Page.Name
+	}
+	return fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102150405"), format)
+}
+
+func (e *Exporter) header() []string {
+	header := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		header[i] = escapeFormula(col.Title)
+	}
+	return header
+}
+
+// escapeFormula 以=、+、-、@开头的文本在Excel中会被当成公式执行,前面加上单引号
+func escapeFormula(str string) string {
+	if str != "" && strings.ContainsRune("=+-@\t\r", rune(str[0])) {
+		return "'" + str
+	}
+	return str
+}
+
+func (e *Exporter) rows() (*sql.Rows, error) {
+	return e.db.Rows()
+}
+
+// each 逐行读取查询结果并转换成导出的单元格内容,rows读取完后关闭
+func (e *Exporter) each(rows *sql.Rows, fn func(record []string) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		data := make(map[string]interface{})
+		if err := e.db.ScanRows(rows, &data); err != nil {
+			return err
+		}
+		record := make([]string, len(e.columns))
+		for i, col := range e.columns {
+			if col.Column == "" {
+				continue
+			}
+			value, err := revealValue(e.op, col.field, data[col.Column])
+			if err != nil {
+				return err
+			}
+			record[i] = col.format(value)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (col *exportColumn) format(value interface{}) string {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		value = rv.Elem().Interface()
+	}
+	var str string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		str = escapeFormula(v)
+	case []byte:
+		str = escapeFormula(string(v))
+	case time.Time:
+		str = v.Format("2006-01-02 15:04:05")
+	case bool:
+		// 布尔值的枚举配置可能是true/false,也可能是1/0
+		if label, ok := col.ValueEnum[strconv.FormatBool(v)]; ok {
+			return escapeFormula(label)
+		}
+		str = "0"
+		if v {
+			str = "1"
+		}
+	default:
+		str = fmt.Sprint(v)
+	}
+	if label, ok := col.ValueEnum[str]; ok {
+		return escapeFormula(label)
+	}
+	return str
+}
+
+// Write 先执行查询再写入w,查询失败时不会写入任何内容
+func (e *Exporter) Write(format string, w io.Writer) error {
+	switch format {
+	case ExportFormatXLSX:
+		return e.WriteXLSX(w)
+	default:
+		return e.WriteCSV(w)
+	}
+}
+
+// WriteCSV 逐行输出,输出过程中出错时在最后一行写入ExportErrorMarker和错误信息
+func (e *Exporter) WriteCSV(w io.Writer) error {
+	rows, err := e.rows()
+	if err != nil {
+		return err
+	}
+	// 写入BOM,避免Excel打开中文乱码
+	if _, err = w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		rows.Close()
+		return err
+	}
+	writer := csv.NewWriter(w)
+	if err = writer.Write(e.header()); err != nil {
+		rows.Close()
+		return err
+	}
+	err = e.each(rows, func(record []string) error {
+		return writer.Write(record)
+	})
+	if err != nil {
+		writer.Write([]string{ExportErrorMarker + err.Error()})
+		writer.Flush()
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteXLSX 生成完整的文件后才写入w,生成过程中出错时不会写入任何内容
+func (e *Exporter) WriteXLSX(w io.Writer) error {
+	rows, err := e.rows()
+	if err != nil {
+		return err
+	}
+	f := excelize.NewFile()
+	defer f.Close()
+	sheet := f.GetSheetName(0)
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		rows.Close()
+		return err
+	}
+	rowIndex := 1
+	writeRow := func(record []string) error {
+		cells := make([]interface{}, len(record))
+		for i, v := range record {
+			cells[i] = v
+		}
+		cell, err := excelize.CoordinatesToCellName(1, rowIndex)
+		if err != nil {
+			return err
+		}
+		rowIndex++
+		return sw.SetRow(cell, cells)
+	}
+	if err = writeRow(e.header()); err != nil {
+		rows.Close()
+		return err
+	}
+	if err = e.each(rows, writeRow); err != nil {
+		return err
+	}
+	if err = sw.Flush(); err != nil {
+		return err
+	}
+	return f.Write(w)
+}
+
+// ParseValueEnum 解析字段的枚举值配置,支持以下几种格式:
+// {"1":"启用"}、{"1":{"text":"启用"}}、[{"value":1,"label":"启用"}]
+func ParseValueEnum(str string) map[string]string {
+	if str == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(str), &obj); err == nil {
+		for key, value := range obj {
+			switch v := value.(type) {
+			case string:
+				result[key] = v
+			case map[string]interface{}:
+				if text, ok := v["text"]; ok {
+					result[key] = fmt.Sprint(text)
+				} else if label, ok := v["label"]; ok {
+					result[key] = fmt.Sprint(label)
+				}
+			}
+		}
+		return result
+	}
+	var list []map[string]interface{}
+	if err := json.Unmarshal([]byte(str), &list); err == nil {
+		for _, item := range list {
+			label, ok := item["label"]
+			if !ok {
+				label = item["text"]
+			}
+			if value, ok := item["value"]; ok && label != nil {
+				result[fmt.Sprint(value)] = fmt.Sprint(label)
+			}
+		}
+	}
+	return result
+}
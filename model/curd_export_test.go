@@ -0,0 +1,82 @@
+package model
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestParseValueEnum(t *testing.T) {
+	for _, str := range []string{
+		`{"1":"启用","0":"禁用"}`,
+		`{"1":{"text":"启用","status":"Success"},"0":{"text":"禁用"}}`,
+		`[{"value":1,"label":"启用"},{"value":0,"label":"禁用"}]`,
+	} {
+		enum := ParseValueEnum(str)
+		if enum["1"] != "启用" || enum["0"] != "禁用" {
+			t.Fatalf("unexpected value enum %v for %s", enum, str)
+		}
+	}
+	col := &exportColumn{ValueEnum: ParseValueEnum(`{"true":"是","false":"否"}`)}
+	if col.format(true) != "是" {
+		t.Fatal("expected bool label")
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "export.db"), false), true)
+
+	md := &Metadata{Name: "Contact", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}, {Name: "balance", Type: "int"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "contact", Title: "联系人", Enable: true, Metadata: md,
+		Fields: []*PageField{{Name: "name", Title: "=姓名"}, {Name: "balance", Title: "余额"}}}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE contacts(id integer primary key autoincrement, name varchar(20), balance int)").Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []map[string]interface{}{{"name": "=HYPERLINK(1)", "balance": -5}, {"name": "@SUM(1)", "balance": 3}, {"name": "tom", "balance": 0}} {
+		if err = Create(nil, "contact", m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e, err := NewExporter(&QueryRequest{PageName: "contact"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = e.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "\xEF\xBB\xBF'=姓名,余额\n'=HYPERLINK(1),-5\n'@SUM(1),3\ntom,0\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected csv %q", buf.String())
+	}
+	// 同一个Exporter多次导出时排序条件不会重复添加
+	buf.Reset()
+	if err = e.WriteCSV(&buf); err != nil || buf.String() != want {
+		t.Fatalf("unexpected csv %q %v", buf.String(), err)
+	}
+	sql := e.db.ToSQL(func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]map[string]interface{}{}) })
+	if strings.Count(sql, QuoteColumn("id")) != 1 {
+		t.Fatalf("unexpected sql %s", sql)
+	}
+
+	// 查询失败时不输出任何内容
+	dbClient.DB().Exec("DROP TABLE contacts")
+	buf.Reset()
+	if err = e.WriteCSV(&buf); err == nil || buf.Len() != 0 {
+		t.Fatalf("expected error without output, got %v %q", err, buf.String())
+	}
+	if err = e.WriteXLSX(&buf); err == nil || buf.Len() != 0 {
+		t.Fatalf("expected error without output, got %v %q", err, buf.String())
+	}
+}
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 查询条件的操作符通过字段名后缀指定,例如 age__gte、name__like、status__in
+const FilterOperatorSeparator = "__"
+
+const (
+	FilterEq      = "eq"
+	FilterNe      = "ne"
+	FilterGt      = "gt"
+	FilterGte     = "gte"
+	FilterLt      = "lt"
+	FilterLte     = "lte"
+	FilterLike    = "like"
+	FilterIn      = "in"
+	FilterNotIn   = "nin"
+	FilterIsNull  = "isnull"
+	FilterBetween = "between"
+)
+
+// Filter 解析后的单个查询条件
+type Filter struct {
+	Field    *MetadataField
+	Operator string
+	Value    interface{}
+}
+
+// FieldByName 根据字段名查找元数据字段,同时兼容驼峰和下划线两种写法
+func (md *Metadata) FieldByName(name string) *MetadataField {
+	if md == nil {
+		return nil
+	}
+	column := LowerSnakeCase(name)
+	for _, field := range md.MetadataFields {
+		if field.Name == name || LowerSnakeCase(field.Name) == column {
+			return field
+		}
+	}
+	return nil
+}
+
+// ParseFilters 把请求中的查询条件解析成Filter
+// 只有ShowInQuery的字段才允许作为查询条件,like只能用于开启了Like的字段,
+// 没有后缀时是精确查询,模糊查询必须使用__like后缀。
+// 当前用户不能查看原值的脱敏字段只能使用isnull,避免通过查询条件推测出原值
+func ParseFilters(op *Operator, md *Metadata, data map[string]interface{}) ([]*Filter, error) {
+	var filters []*Filter
+	for key, value := range data {
+		name, operator := key, ""
+		if i := strings.LastIndex(key, FilterOperatorSeparator); i > 0 {
+			name, operator = key[:i], key[i+len(FilterOperatorSeparator):]
+		}
+		field := md.FieldByName(name)
+		if field == nil {
+			return nil, fmt.Errorf("不存在查询字段:%s", name)
+		}
+		if !field.ShowInQuery {
+			return nil, fmt.Errorf("字段%s不支持查询", name)
+		}
+		if operator == "" {
+			operator = FilterEq
+		}
+		if err := checkFilterValue(field, operator, value); err != nil {
+			return nil, err
+		}
+		if field.Mask != "" && operator != FilterIsNull && !canUnmask(op, field) {
+			return nil, fmt.Errorf("脱敏字段%s只支持isnull查询", name)
+		}
+		filter := &Filter{Field: field, Operator: operator, Value: value}
+		if field.Encrypt {
+			var err error
+			if filter, err = encryptedFilter(md, filter); err != nil {
+				return nil, err
+			}
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+func checkFilterValue(field *MetadataField, op string, value interface{}) error {
+	switch op {
+	case FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte:
+		return nil
+	case FilterLike:
+		if !field.Like {
+			return fmt.Errorf("字段%s不支持like查询", field.Name)
+		}
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("字段%s的like查询条件必须是字符串", field.Name)
+		}
+	case FilterIn, FilterNotIn:
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("字段%s的%s查询条件必须是数组", field.Name, op)
+		}
+	case FilterIsNull:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("字段%s的isnull查询条件必须是true或者false", field.Name)
+		}
+	case FilterBetween:
+		list, ok := value.([]interface{})
+		if !ok || len(list) != 2 {
+			return fmt.Errorf("字段%s的between查询条件必须是包含两个元素的数组", field.Name)
+		}
+	default:
+		return fmt.Errorf("不支持的查询操作符:%s", op)
+	}
+	return nil
+}
+
+// Expression 转换成gorm的查询表达式
+func (f *Filter) Expression() clause.Expression {
+	column := clause.Column{Name: LowerSnakeCase(f.Field.Name)}
+	switch f.Operator {
+	case FilterNe:
+		return clause.Neq{Column: column, Value: f.Value}
+	case FilterGt:
+		return clause.Gt{Column: column, Value: f.Value}
+	case FilterGte:
+		return clause.Gte{Column: column, Value: f.Value}
+	case FilterLt:
+		return clause.Lt{Column: column, Value: f.Value}
+	case FilterLte:
+		return clause.Lte{Column: column, Value: f.Value}
+	case FilterLike:
+		return likeExpression(column, f.Value.(string))
+	case FilterIn:
+		return clause.IN{Column: column, Values: f.Value.([]interface{})}
+	case FilterNotIn:
+		return clause.Not(clause.IN{Column: column, Values: f.Value.([]interface{})})
+	case FilterIsNull:
+		if f.Value.(bool) {
+			return clause.Expr{SQL: "? IS NULL", Vars: []interface{}{column}}
+		}
+		return clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{column}}
+	case FilterBetween:
+		list := f.Value.([]interface{})
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []interface{}{column, list[0], list[1]}}
+	default:
+		return clause.Eq{Column: column, Value: f.Value}
+	}
+}
+
+// likeEscape LIKE的转义字符,没有使用反斜杠是因为MySQL和Postgres对字符串中的反斜杠处理不一样
+const likeEscape = "!"
+
+var likeReplacer = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// likeExpression 包含value的模糊查询,value中的%和_按照普通字符匹配
+func likeExpression(column clause.Column, value string) clause.Expression {
+	return clause.Expr{SQL: "? LIKE ? ESCAPE '" + likeEscape + "'", Vars: []interface{}{column, "%" + likeReplacer.Replace(value) + "%"}}
+}
+
+// ApplyFilters 把查询条件加到db上
+func ApplyFilters(db *gorm.DB, filters []*Filter) *gorm.DB {
+	for _, f := range filters {
+		db = db.Where(f.Expression())
+	}
+	return db
+}
@@ -0,0 +1,50 @@
+package model
+
+import "testing"
+
+func TestParseFilters(t *testing.T) {
+	md := &Metadata{
+		MetadataFields: []*MetadataField{
+			{Name: "name", ShowInQuery: true, Like: true},
+			{Name: "age", ShowInQuery: true},
+			{Name: "createdAt", ShowInQuery: true},
+			{Name: "password"},
+		},
+	}
+
+	filters, err := ParseFilters(nil, md, map[string]interface{}{"name": "tom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filters[0].Operator != FilterEq {
+		t.Fatalf("expected eq, got %s", filters[0].Operator)
+	}
+	filters, err = ParseFilters(nil, md, map[string]interface{}{"name__like": "tom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filters[0].Operator != FilterLike {
+		t.Fatalf("expected like, got %s", filters[0].Operator)
+	}
+
+	filters, err = ParseFilters(nil, md, map[string]interface{}{"created_at__between": []interface{}{"2023-01-01", "2023-02-01"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filters[0].Field.Name != "createdAt" || filters[0].Operator != FilterBetween {
+		t.Fatalf("unexpected filter %+v", filters[0])
+	}
+
+	for _, data := range []map[string]interface{}{
+		{"password": "123"},
+		{"unknown": 1},
+		{"age__like": "1"},
+		{"age__in": 1},
+		{"age__between": []interface{}{1}},
+		{"age__foo": 1},
+	} {
+		if _, err = ParseFilters(nil, md, data); err == nil {
+			t.Fatalf("expected error for %v", data)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Knetic/govaluate"
+	"gorm.io/gorm"
+)
+
+const (
+	HookBeforeCreate = "beforeCreate"
+	HookAfterCreate  = "afterCreate"
+	HookBeforeUpdate = "beforeUpdate"
+	HookAfterUpdate  = "afterUpdate"
+	HookBeforeDelete = "beforeDelete"
+	HookAfterDelete  = "afterDelete"
+)
+
+const (
+	HookTypeValidate = "validate"
+	HookTypeSet      = "set"
+)
+
+var hookEvents = map[string]bool{
+	HookBeforeCreate: true,
+	HookAfterCreate:  true,
+	HookBeforeUpdate: true,
+	HookAfterUpdate:  true,
+	HookBeforeDelete: true,
+	HookAfterDelete:  true,
+}
+
+// HookError 钩子中止操作时返回的错误,Message会直接返回给客户端
+type HookError struct {
+	Message string
+}
+
+func (e *HookError) Error() string {
+	return e.Message
+}
+
+// AbortHook 在钩子中返回这个错误可以中止操作并回滚事务
+func AbortHook(format string, args ...interface{}) error {
+	return &HookError{Message: fmt.Sprintf(format, args...)}
+}
+
+// HookContext 钩子执行时的上下文
+type HookContext struct {
+	// Tx 当前操作的事务,钩子中的数据库操作需要使用Tx,返回错误时一起回滚
+	Tx       *gorm.DB
+	Operator *Operator
+	Page     *Page
+	Event    string
+	ID       interface{}
+	// Data 新增和修改时是提交的记录,before钩子中可以修改;删除时是删除前的记录,key都是字段名
+	Data map[string]interface{}
+	// Before 修改和删除前数据库中的记录,新增时为nil
+	Before map[string]interface{}
+}
+
+type HookFunc func(ctx *HookContext) error
+
+var (
+	hookLock  sync.RWMutex
+	hookFuncs = make(map[string]map[string][]HookFunc)
+)
+
+// RegisterHook 注册页面的钩子函数,按照注册顺序在页面配置的表达式钩子之后执行,
+// 子表使用元数据名称作为页面名称
+func RegisterHook(pageName, event string, fn HookFunc) {
+	if !hookEvents[event] {
+		panic("不支持的钩子:" + event)
+	}
+	hookLock.Lock()
+	defer hookLock.Unlock()
+	if hookFuncs[pageName] == nil {
+		hookFuncs[pageName] = make(map[string][]HookFunc)
+	}
+	hookFuncs[pageName][event] = append(hookFuncs[pageName][event], fn)
+}
+
+func registeredHooks(pageName, event string) []HookFunc {
+	hookLock.RLock()
+	defer hookLock.RUnlock()
+	return hookFuncs[pageName][event]
+}
+
+// hasHooks 页面是否有event的钩子,没有钩子时不需要读取记录
+func hasHooks(page *Page, event string) bool {
+	if len(registeredHooks(page.Name, event)) > 0 {
+		return true
+	}
+	for _, hook := range page.Hooks {
+		if hook.Enable && hook.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+// runHooks 先按照Index执行页面配置的表达式钩子,再执行注册的钩子函数,任意一个返回错误都会中止
+func runHooks(ctx *HookContext) error {
+	for _, hook := range ctx.Page.Hooks {
+		if !hook.Enable || hook.Event != ctx.Event {
+			continue
+		}
+		if err := runExpressionHook(ctx, hook); err != nil {
+			return err
+		}
+	}
+	for _, fn := range registeredHooks(ctx.Page.Name, ctx.Event) {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRecordHooks 新增和修改记录时执行钩子,before钩子修改的字段会保存到m中
+func runRecordHooks(tx *gorm.DB, op *Operator, page *Page, event string, id interface{}, m, before map[string]interface{}) error {
+	if !hasHooks(page, event) {
+		return nil
+	}
+	var beforeData map[string]interface{}
+	if before != nil {
+		beforeData = hookRecord(page.Metadata, before)
+	}
+	return runHooks(&HookContext{Tx: tx, Operator: op, Page: page, Event: event, ID: id, Data: m, Before: beforeData})
+}
+
+// hookRecord 把数据库中的记录转换成key为字段名的记录
+func hookRecord(md *Metadata, row map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+	for _, field := range md.MetadataFields {
+		if value, ok := row[LowerSnakeCase(field.Name)]; ok {
+			value = derefValue(value)
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			data[field.Name] = value
+		}
+	}
+	return data
+}
+
+// runExpressionHook 执行表达式钩子,validate的结果为false时中止操作,set把结果保存到Field
+func runExpressionHook(ctx *HookContext, hook *PageHook) error {
+	expr, err := compileHookExpression(hook.Expression)
+	if err != nil {
+		return err
+	}
+	result, err := expr.Eval(&hookParameters{md: ctx.Page.Metadata, data: ctx.Data})
+	if err != nil {
+		return fmt.Errorf("执行钩子%s失败:%w", hook.Expression, err)
+	}
+	switch hook.Type {
+	case HookTypeValidate:
+		ok, isBool := result.(bool)
+		if !isBool {
+			return fmt.Errorf("校验表达式%s的结果不是bool", hook.Expression)
+		}
+		if ok {
+			return nil
+		}
+		message := hook.Message
+		if message == "" {
+			message = "校验失败:" + hook.Expression
+		}
+		if hook.Field != "" {
+			return ValidationErrors{hook.Field: message}
+		}
+		return &HookError{Message: message}
+	case HookTypeSet:
+		field := ctx.Page.Metadata.FieldByName(hook.Field)
+		if field == nil {
+			return fmt.Errorf("钩子的字段%s不存在", hook.Field)
+		}
+		if result != nil {
+			if result, err = ConvertFieldValue(field, result); err != nil {
+				return fmt.Errorf("%s%s", fieldTitle(field), err.Error())
+			}
+		}
+		ctx.Data[field.Name] = result
+		return nil
+	}
+	return fmt.Errorf("不支持的钩子类型:%s", hook.Type)
+}
+
+// CheckPageHooks 保存页面配置时检查钩子的配置和表达式语法
+func CheckPageHooks(hooks []*PageHook) error {
+	for _, hook := range hooks {
+		if !hookEvents[hook.Event] {
+			return fmt.Errorf("不支持的钩子:%s", hook.Event)
+		}
+		switch hook.Type {
+		case HookTypeValidate:
+		case HookTypeSet:
+			if hook.Field == "" {
+				return fmt.Errorf("钩子%s必须指定字段", hook.Expression)
+			}
+			if !strings.HasPrefix(hook.Event, "before") || hook.Event == HookBeforeDelete {
+				return fmt.Errorf("只有beforeCreate和beforeUpdate可以计算字段")
+			}
+		default:
+			return fmt.Errorf("不支持的钩子类型:%s", hook.Type)
+		}
+		if _, err := compileHookExpression(hook.Expression); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var hookExpressions sync.Map
+
+// compileHookExpression 解析表达式,解析结果按照表达式缓存
+func compileHookExpression(expression string) (*govaluate.EvaluableExpression, error) {
+	if v, ok := hookExpressions.Load(expression); ok {
+		return v.(*govaluate.EvaluableExpression), nil
+	}
+	expr, err := govaluate.NewEvaluableExpressionWithFunctions(expression, hookFunctions)
+	if err != nil {
+		return nil, fmt.Errorf("钩子表达式%s错误:%w", expression, err)
+	}
+	hookExpressions.Store(expression, expr)
+	return expr, nil
+}
+
+// hookParameters 表达式中可以使用的变量,只能访问元数据中定义的字段,
+// 时间转换成Unix秒,可以和'2023-01-01'这样的日期常量比较
+type hookParameters struct {
+	md   *Metadata
+	data map[string]interface{}
+}
+
+func (p *hookParameters) Get(name string) (interface{}, error) {
+	field := p.md.FieldByName(name)
+	if field == nil {
+		return nil, errors.New("不存在字段:" + name)
+	}
+	return hookValue(p.data[field.Name]), nil
+}
+
+func hookValue(value interface{}) interface{} {
+	switch v := derefValue(value).(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return float64(v.Unix())
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return float64(v.Unix())
+	default:
+		return v
+	}
+}
+
+// hookFunctions 表达式中可以调用的函数,表达式只能使用这些函数,不能访问其他数据
+var hookFunctions = map[string]govaluate.ExpressionFunction{
+	"len": func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errors.New("len只能有一个参数")
+		}
+		switch v := args[0].(type) {
+		case nil:
+			return float64(0), nil
+		case string:
+			return float64(utf8.RuneCountInString(v)), nil
+		}
+		rv := reflect.ValueOf(args[0])
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map {
+			return float64(rv.Len()), nil
+		}
+		return nil, errors.New("len的参数必须是字符串或者数组")
+	},
+	"isEmpty": func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, errors.New("isEmpty只能有一个参数")
+		}
+		return isEmptyValue(args[0]), nil
+	},
+	"now": func(args ...interface{}) (interface{}, error) {
+		return float64(time.Now().Unix()), nil
+	},
+	"round": func(args ...interface{}) (interface{}, error) {
+		if len(args) == 0 || len(args) > 2 {
+			return nil, errors.New("round的参数是数字和小数位数")
+		}
+		x, ok := args[0].(float64)
+		if !ok {
+			return nil, errors.New("round的参数必须是数字")
+		}
+		places := float64(0)
+		if len(args) == 2 {
+			if places, ok = args[1].(float64); !ok {
+				return nil, errors.New("round的小数位数必须是数字")
+			}
+		}
+		pow := math.Pow(10, places)
+		return math.Round(x*pow) / pow, nil
+	},
+	"contains": func(args ...interface{}) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, errors.New("contains的参数是字符串和子串")
+		}
+		return strings.Contains(fmt.Sprint(args[0]), fmt.Sprint(args[1])), nil
+	},
+	"concat": func(args ...interface{}) (interface{}, error) {
+		var sb strings.Builder
+		for _, arg := range args {
+			if arg != nil {
+				sb.WriteString(fmt.Sprint(arg))
+			}
+		}
+		return sb.String(), nil
+	},
+}
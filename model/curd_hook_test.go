@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRunHooks(t *testing.T) {
+	page := &Page{Name: "hook_order", Metadata: &Metadata{Name: "HookOrder", MetadataFields: []*MetadataField{
+		{Name: "price", Type: "decimal"},
+		{Name: "quantity", Type: "int"},
+		{Name: "amount", Type: "decimal"},
+		{Name: "code", Type: "varchar"},
+	}}, Hooks: []*PageHook{
+		{Event: HookBeforeCreate, Type: HookTypeValidate, Field: "quantity", Expression: "quantity > 0", Message: "数量必须大于0", Enable: true},
+		{Event: HookBeforeCreate, Type: HookTypeSet, Field: "amount", Expression: "round(price * quantity, 1)", Enable: true},
+		{Event: HookBeforeCreate, Type: HookTypeSet, Field: "code", Expression: "concat('SO-', quantity)", Enable: true},
+		{Event: HookBeforeCreate, Type: HookTypeValidate, Expression: "false", Enable: false},
+	}}
+	if err := CheckPageHooks(page.Hooks); err != nil {
+		t.Fatal(err)
+	}
+
+	var called *HookContext
+	RegisterHook(page.Name, HookBeforeCreate, func(ctx *HookContext) error {
+		called = ctx
+		if ctx.Data["code"] == "SO-13" {
+			return AbortHook("编号%s已经存在", ctx.Data["code"])
+		}
+		return nil
+	})
+
+	data := map[string]interface{}{"price": 1.25, "quantity": 3}
+	if err := runHooks(&HookContext{Page: page, Event: HookBeforeCreate, Data: data}); err != nil {
+		t.Fatal(err)
+	}
+	if data["amount"] != 3.8 || data["code"] != "SO-3" || called == nil {
+		t.Fatalf("unexpected data %v", data)
+	}
+
+	err := runHooks(&HookContext{Page: page, Event: HookBeforeCreate, Data: map[string]interface{}{"price": 1, "quantity": 0}})
+	if errs, ok := err.(ValidationErrors); !ok || errs["quantity"] != "数量必须大于0" {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	err = runHooks(&HookContext{Page: page, Event: HookBeforeCreate, Data: map[string]interface{}{"price": 1, "quantity": 13}})
+	var hookErr *HookError
+	if !errors.As(err, &hookErr) || hookErr.Message != "编号SO-13已经存在" {
+		t.Fatalf("expected HookError, got %v", err)
+	}
+}
+
+func TestCheckPageHooks(t *testing.T) {
+	for _, hook := range []*PageHook{
+		{Event: "beforeSave", Type: HookTypeValidate, Expression: "true"},
+		{Event: HookBeforeCreate, Type: "script", Expression: "true"},
+		{Event: HookBeforeCreate, Type: HookTypeSet, Expression: "1"},
+		{Event: HookAfterUpdate, Type: HookTypeSet, Field: "amount", Expression: "1"},
+		{Event: HookBeforeUpdate, Type: HookTypeValidate, Expression: "price >"},
+	} {
+		if err := CheckPageHooks([]*PageHook{hook}); err == nil {
+			t.Errorf("expected error for %+v", hook)
+		}
+	}
+}
+
+func TestHookParameters(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "name", Type: "varchar"}}}
+	expr, err := compileHookExpression("len(name) <= 3 && !isEmpty(name) && contains(name, 'b')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := []byte("abc")
+	if result, err := expr.Eval(&hookParameters{md: md, data: map[string]interface{}{"name": &name}}); err != nil || result != true {
+		t.Fatalf("unexpected result %v %v", result, err)
+	}
+	// 表达式只能访问元数据中的字段
+	expr, _ = compileHookExpression("password == ''")
+	if _, err = expr.Eval(&hookParameters{md: md, data: map[string]interface{}{"password": ""}}); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
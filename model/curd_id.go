@@ -0,0 +1,267 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CloudSilk/curd/config"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// IDStrategyAuto 使用数据库的自增ID
+	IDStrategyAuto = "auto"
+	// IDStrategyUUID 新增时生成UUID
+	IDStrategyUUID = "uuid"
+	// IDStrategySnowflake 新增时使用雪花算法生成64位的数字ID
+	IDStrategySnowflake = "snowflake"
+)
+
+// IDStrategy 返回元数据的主键生成方式,没有配置时字符串类型的id使用uuid,其他使用数据库自增
+func IDStrategy(md *Metadata) string {
+	if md.IDStrategy != "" {
+		return md.IDStrategy
+	}
+	if field := md.FieldByName("id"); field != nil && isStringType(field.Type) {
+		return IDStrategyUUID
+	}
+	return IDStrategyAuto
+}
+
+// CheckIDStrategy 保存元数据时检查主键生成方式和id字段的类型是否匹配
+func CheckIDStrategy(md *Metadata) error {
+	field := md.FieldByName("id")
+	switch md.IDStrategy {
+	case "", IDStrategyAuto:
+	case IDStrategyUUID:
+		if field != nil && !isStringType(field.Type) {
+			return fmt.Errorf("主键生成方式为uuid时id字段必须是字符串类型")
+		}
+	case IDStrategySnowflake:
+		if field != nil && field.Type != "bigint" && field.Type != "int64" {
+			return fmt.Errorf("主键生成方式为snowflake时id字段必须是bigint类型")
+		}
+	default:
+		return fmt.Errorf("不支持的主键生成方式:%s", md.IDStrategy)
+	}
+	return nil
+}
+
+// newRecordID 根据主键生成方式生成新记录的ID,使用数据库自增时返回nil
+func newRecordID(md *Metadata) interface{} {
+	switch IDStrategy(md) {
+	case IDStrategyUUID:
+		return uuid.New().String()
+	case IDStrategySnowflake:
+		return defaultSnowflake().Next()
+	}
+	return nil
+}
+
+// snowflakeEpoch 雪花算法的起始时间 2020-01-01
+const snowflakeEpoch int64 = 1577836800000
+
+// Snowflake 41位毫秒时间戳、10位节点ID、12位序号
+type Snowflake struct {
+	lock sync.Mutex
+	node int64
+	last int64
+	seq  int64
+}
+
+func NewSnowflake(node int64) *Snowflake {
+	return &Snowflake{node: node & 0x3FF}
+}
+
+func (s *Snowflake) Next() int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now().UnixMilli() - snowflakeEpoch
+	// 时钟回拨时继续使用上一次的时间
+	if now < s.last {
+		now = s.last
+	}
+	if now == s.last {
+		s.seq = (s.seq + 1) & 0xFFF
+		// 同一毫秒的序号用完后借用下一毫秒
+		if s.seq == 0 {
+			now++
+		}
+	} else {
+		s.seq = 0
+	}
+	s.last = now
+	return now<<22 | s.node<<12 | s.seq
+}
+
+var (
+	snowflakeOnce sync.Once
+	snowflake     *Snowflake
+)
+
+func defaultSnowflake() *Snowflake {
+	snowflakeOnce.Do(func() {
+		snowflake = NewSnowflake(config.DefaultConfig.Snowflake.NodeID)
+	})
+	return snowflake
+}
+
+// CodeSequence 编码规则的流水号,每个租户、字段和周期一条记录
+type CodeSequence struct {
+	TenantID  string `gorm:"primaryKey;size:36"`
+	Name      string `gorm:"primaryKey;size:200;comment:元数据名称.字段名"`
+	Period    string `gorm:"primaryKey;size:20;comment:流水号的周期,由编码规则中的日期决定"`
+	Value     int64  `gorm:"comment:当前流水号"`
+	UpdatedAt time.Time
+}
+
+var codeRuleToken = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// codeDateLayouts 编码规则中的日期格式转换成Go的格式,较长的放在前面
+var codeDateLayouts = []string{"yyyy", "2006", "yy", "06", "MM", "01", "dd", "02", "HH", "15", "mm", "04", "ss", "05"}
+
+type codeRule struct {
+	parts []codePart
+}
+
+// codePart 编码规则的一段,layout和seq都为空时是固定的文本
+type codePart struct {
+	text   string
+	layout string
+	seq    int
+}
+
+// parseCodeRule 解析编码规则,例如PO-{yyyyMMdd}-{seq:4},{seq:4}是补齐到4位的流水号,
+// 流水号按照规则中的日期重置,例如{yyyyMMdd}每天重置,{yyyyMM}每月重置,没有日期时不重置
+func parseCodeRule(rule string) (*codeRule, error) {
+	r := &codeRule{}
+	seqs := 0
+	last := 0
+	for _, loc := range codeRuleToken.FindAllStringSubmatchIndex(rule, -1) {
+		if loc[0] > last {
+			r.parts = append(r.parts, codePart{text: rule[last:loc[0]]})
+		}
+		last = loc[1]
+		token := rule[loc[2]:loc[3]]
+		if token == "" {
+			return nil, fmt.Errorf("编码规则%s格式错误", rule)
+		}
+		if token == "seq" || strings.HasPrefix(token, "seq:") {
+			width := 1
+			if token != "seq" {
+				n, err := strconv.Atoi(strings.TrimPrefix(token, "seq:"))
+				if err != nil || n <= 0 || n > 20 {
+					return nil, fmt.Errorf("编码规则%s的流水号位数错误", rule)
+				}
+				width = n
+			}
+			seqs++
+			r.parts = append(r.parts, codePart{seq: width})
+			continue
+		}
+		layout := token
+		for i := 0; i < len(codeDateLayouts); i += 2 {
+			layout = strings.ReplaceAll(layout, codeDateLayouts[i], codeDateLayouts[i+1])
+		}
+		if strings.ContainsAny(layout, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			return nil, fmt.Errorf("编码规则%s不支持%s", rule, token)
+		}
+		r.parts = append(r.parts, codePart{layout: layout})
+	}
+	if last < len(rule) {
+		r.parts = append(r.parts, codePart{text: rule[last:]})
+	}
+	if seqs != 1 {
+		return nil, fmt.Errorf("编码规则%s必须包含一个{seq}", rule)
+	}
+	return r, nil
+}
+
+// period 流水号的周期,是规则中所有日期格式化后的结果
+func (r *codeRule) period(now time.Time) string {
+	var period []string
+	for _, part := range r.parts {
+		if part.layout != "" {
+			period = append(period, now.Format(part.layout))
+		}
+	}
+	return strings.Join(period, "-")
+}
+
+func (r *codeRule) format(now time.Time, seq int64) string {
+	var b strings.Builder
+	for _, part := range r.parts {
+		switch {
+		case part.layout != "":
+			b.WriteString(now.Format(part.layout))
+		case part.seq > 0:
+			b.WriteString(fmt.Sprintf("%0*d", part.seq, seq))
+		default:
+			b.WriteString(part.text)
+		}
+	}
+	return b.String()
+}
+
+// CheckCodeRules 保存元数据时检查编码规则
+func CheckCodeRules(md *Metadata) error {
+	for _, field := range md.MetadataFields {
+		if field.CodeRule == "" {
+			continue
+		}
+		if !isStringType(field.Type) {
+			return fmt.Errorf("编码规则字段%s必须是字符串类型", field.Name)
+		}
+		if _, err := parseCodeRule(field.CodeRule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextSequence 流水号加1并返回新的值。在新增记录的事务中执行,
+// 同一个流水号的记录被锁住直到事务结束,所以并发新增时不会重复,新增失败时流水号也会回滚
+func nextSequence(tx *gorm.DB, tenantID, name, period string) (int64, error) {
+	seq := &CodeSequence{TenantID: tenantID, Name: name, Period: period, Value: 1, UpdatedAt: time.Now()}
+	err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "name"}, {Name: "period"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"value":      gorm.Expr(tx.Statement.Quote(tx.NamingStrategy.TableName("CodeSequence")) + "." + tx.Statement.Quote("value") + " + 1"),
+			"updated_at": seq.UpdatedAt,
+		}),
+	}).Create(seq).Error
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Model(&CodeSequence{}).Where("tenant_id = ? and name = ? and period = ?", tenantID, name, period).Pluck("value", &seq.Value).Error
+	return seq.Value, err
+}
+
+// generateCodes 给没有传入值的编码规则字段生成编号
+func generateCodes(tx *gorm.DB, md *Metadata, m map[string]interface{}) error {
+	// 编号按租户分别计数,租户ID已经由applyTenant写入
+	tenantID, _ := m[tenantKey(md)].(string)
+	now := time.Now()
+	for _, field := range md.MetadataFields {
+		if field.CodeRule == "" || !isEmptyValue(m[field.Name]) {
+			continue
+		}
+		rule, err := parseCodeRule(field.CodeRule)
+		if err != nil {
+			return err
+		}
+		period := rule.period(now)
+		seq, err := nextSequence(tx, tenantID, md.Name+"."+field.Name, period)
+		if err != nil {
+			return err
+		}
+		m[field.Name] = rule.format(now, seq)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestParseCodeRule(t *testing.T) {
+	now := time.Date(2024, 3, 5, 8, 9, 10, 0, time.Local)
+	for _, c := range []struct {
+		rule, code, period string
+	}{
+		{"PO-{yyyyMMdd}-{seq:4}", "PO-20240305-0007", "20240305"},
+		{"{yyMM}{seq:3}", "2403007", "2403"},
+		{"SO{yyyy}/{MM}-{seq}", "SO2024/03-7", "2024-03"},
+		{"T{HHmmss}{seq:2}", "T08091007", "080910"},
+		{"C{seq:5}", "C00007", ""},
+	} {
+		r, err := parseCodeRule(c.rule)
+		if err != nil {
+			t.Fatalf("%s: %v", c.rule, err)
+		}
+		if code, period := r.format(now, 7), r.period(now); code != c.code || period != c.period {
+			t.Errorf("%s: expected %s %s, got %s %s", c.rule, c.code, c.period, code, period)
+		}
+	}
+	for _, rule := range []string{"PO-{yyyyMMdd}", "{seq}{seq}", "{seq:0}", "{seq:a}", "{abc}{seq}", "{}{seq}"} {
+		if _, err := parseCodeRule(rule); err == nil {
+			t.Errorf("%s: expected error", rule)
+		}
+	}
+}
+
+func TestSnowflake(t *testing.T) {
+	s := NewSnowflake(5)
+	last := int64(0)
+	for i := 0; i < 10000; i++ {
+		id := s.Next()
+		if id <= last || (id>>12)&0x3FF != 5 {
+			t.Fatalf("unexpected id %d after %d", id, last)
+		}
+		last = id
+	}
+}
+
+func TestIDStrategy(t *testing.T) {
+	for _, c := range []struct {
+		md       *Metadata
+		strategy string
+		valid    bool
+	}{
+		{&Metadata{MetadataFields: []*MetadataField{{Name: "id", Type: "varchar"}}}, IDStrategyUUID, true},
+		{&Metadata{MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}}}, IDStrategyAuto, true},
+		{&Metadata{IDStrategy: IDStrategySnowflake, MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}}}, IDStrategySnowflake, true},
+		{&Metadata{IDStrategy: IDStrategySnowflake, MetadataFields: []*MetadataField{{Name: "id", Type: "varchar"}}}, IDStrategySnowflake, false},
+		{&Metadata{IDStrategy: IDStrategyUUID, MetadataFields: []*MetadataField{{Name: "id", Type: "int"}}}, IDStrategyUUID, false},
+		{&Metadata{IDStrategy: "random"}, "random", false},
+	} {
+		if strategy := IDStrategy(c.md); strategy != c.strategy {
+			t.Errorf("%+v: expected %s, got %s", c.md, c.strategy, strategy)
+		}
+		if err := CheckIDStrategy(c.md); (err == nil) != c.valid {
+			t.Errorf("%+v: unexpected result %v", c.md, err)
+		}
+	}
+}
+
+func TestCodeRuleRecords(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "code.db"), false), true)
+
+	md := &Metadata{Name: "PurchaseOrder", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "varchar"}, {Name: "name", Type: "varchar"}, {Name: "code", Type: "varchar", CodeRule: "PO-{yyyyMMdd}-{seq:4}"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "purchase_order", Title: "采购单", Enable: true, Metadata: md}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE purchase_orders(id varchar(36) primary key, name varchar(20), code varchar(50))").Error; err != nil {
+		t.Fatal(err)
+	}
+	// 前一天的流水号不影响今天的编号
+	yesterday := time.Now().AddDate(0, 0, -1).Format("20060102")
+	dbClient.DB().Create(&CodeSequence{Name: "PurchaseOrder.code", Period: yesterday, Value: 99})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- Create(nil, "purchase_order", map[string]interface{}{"name": "apple"})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	m := map[string]interface{}{"name": "pear", "code": "PO-MANUAL"}
+	if err = Create(nil, "purchase_order", m); err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := m["id"].(string); len(id) != 36 {
+		t.Fatalf("expected uuid, got %v", m["id"])
+	}
+	if err = Copy(nil, "purchase_order", m["id"].(string)); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := GetAll(nil, "purchase_order")
+	if err != nil || len(list) != 7 {
+		t.Fatalf("unexpected records %v %v", list, err)
+	}
+	prefix := "PO-" + time.Now().Format("20060102") + "-"
+	codes := make(map[string]bool)
+	for _, record := range list {
+		code := record["code"].(string)
+		if code != "PO-MANUAL" && !strings.HasPrefix(code, prefix) {
+			t.Fatalf("unexpected code %s", code)
+		}
+		codes[code] = true
+	}
+	if len(codes) != 7 || !codes[prefix+"0001"] || !codes[prefix+"0006"] {
+		t.Fatalf("unexpected codes %v", codes)
+	}
+}
+
+func TestCodeRuleTenants(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "code_tenant.db"), false), true)
+
+	md := &Metadata{Name: "TenantOrder", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "varchar"}, {Name: "tenantID", Type: "varchar"}, {Name: "code", Type: "varchar", CodeRule: "SO-{seq:3}"},
+	}}
+	if err := dbClient.DB().Create(&Page{Name: "tenant_order", Title: "订单", Enable: true, Metadata: md}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := dbClient.DB().Exec("CREATE TABLE tenant_orders(id varchar(36) primary key, tenant_id varchar(36), code varchar(50))").Error; err != nil {
+		t.Fatal(err)
+	}
+	// 每个租户的流水号单独计数
+	for i, c := range []struct{ tenantID, code string }{{"t1", "SO-001"}, {"t2", "SO-001"}, {"t1", "SO-002"}} {
+		m := map[string]interface{}{}
+		if err := Create(&Operator{TenantID: c.tenantID}, "tenant_order", m); err != nil {
+			t.Fatal(err)
+		}
+		if m["code"] != c.code {
+			t.Fatalf("%d: expected %s, got %v", i, c.code, m["code"])
+		}
+	}
+	var count int64
+	dbClient.DB().Model(&CodeSequence{}).Where("tenant_id IN ?", []string{"t1", "t2"}).Count(&count)
+	if count != 2 {
+		t.Fatalf("expected 2 sequences, got %d", count)
+	}
+}
@@ -0,0 +1,309 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/CloudSilk/pkg/model"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+type ImportRequest struct {
+	PageName string
+	Operator *Operator
+	//为true时所有数据都导入成功才提交,否则只导入校验通过的数据
+	Transaction bool
+}
+
+type ImportFile struct {
+	Name   string
+	Reader io.Reader
+}
+
+// ImportRowResult 每一行数据的导入结果,Row是数据在文件中的行号,表头是第1行
+type ImportRowResult struct {
+	File    string           `json:"file"`
+	Row     int              `json:"row"`
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Errors  ValidationErrors `json:"errors"`
+}
+
+type ImportResponse struct {
+	model.CommonResponse
+	SuccessCount int                `json:"successCount"`
+	FailureCount int                `json:"failureCount"`
+	Data         []*ImportRowResult `json:"data"`
+}
+
+type importColumn struct {
+	Field     *MetadataField
+	ValueEnum map[string]string
+}
+
+var errImportRollback = errors.New("导入失败,已回滚")
+
+func Import(req *ImportRequest, files []*ImportFile, resp *ImportResponse) {
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	if err = checkImportFiles(page, len(files)); err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
+
+	importFiles := func(tx *gorm.DB) error {
+		for _, file := range files {
+			err := importFile(tx, req.Operator, page, file, resp)
+			if err != nil {
+				return err
+			}
+		}
+		if req.Transaction && resp.FailureCount > 0 {
+			return errImportRollback
+		}
+		return nil
+	}
+
+	if req.Transaction {
+		err = dbClient.DB().Transaction(importFiles)
+	} else {
+		err = importFiles(dbClient.DB())
+	}
+	if err == errImportRollback {
+		resp.Code = model.BadRequest
+		resp.Message = fmt.Sprintf("%d行数据导入失败,已全部回滚", resp.FailureCount)
+		resp.SuccessCount = 0
+		for _, result := range resp.Data {
+			result.Success = false
+			if result.Message == "" {
+				result.Message = "已回滚"
+			}
+		}
+	} else if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+	}
+}
+
+// checkImportFiles 按照工具栏的配置检查上传的文件数量,没有开启ImportMulti时只能上传一个文件
+func checkImportFiles(page *Page, count int) error {
+	toolBar := page.ToolBar
+	if toolBar == nil {
+		toolBar = &PageToolBar{}
+	}
+	if count > 1 && !toolBar.ImportMulti {
+		return errors.New("只能导入一个文件")
+	}
+	if toolBar.ImportMaxCount > 0 && count > int(toolBar.ImportMaxCount) {
+		return fmt.Errorf("最多导入%d个文件", toolBar.ImportMaxCount)
+	}
+	return nil
+}
+
+func importFile(tx *gorm.DB, op *Operator, page *Page, file *ImportFile, resp *ImportResponse) error {
+	rows, err := openImportRows(file)
+	if err != nil {
+		return fmt.Errorf("%s:%v", file.Name, err)
+	}
+	defer rows.Close()
+	header, err := rows.Next()
+	if err == io.EOF {
+		return fmt.Errorf("%s:没有表头", file.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("%s:%v", file.Name, err)
+	}
+	columns, err := importColumns(page, header)
+	if err != nil {
+		return fmt.Errorf("%s:%v", file.Name, err)
+	}
+
+	for rowNum := 2; ; rowNum++ {
+		row, err := rows.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%s第%d行:%v", file.Name, rowNum, err)
+		}
+		if isEmptyRow(row) {
+			continue
+		}
+		result := &ImportRowResult{File: file.Name, Row: rowNum}
+		resp.Data = append(resp.Data, result)
+
+		data := importRowToData(columns, row)
+		// 每一行在单独的事务中新增,数据权限、钩子、审计或者发件箱失败时整行回滚;
+		// 所有数据在同一个事务中导入时使用SavePoint,避免一行失败导致整个事务不可用
+		err = tx.Transaction(func(tx *gorm.DB) error {
+			return createRecord(tx, op, page, data)
+		})
+		if err != nil {
+			result.Message = err.Error()
+			if errs, ok := err.(ValidationErrors); ok {
+				result.Errors = errs
+			}
+			resp.FailureCount++
+			continue
+		}
+		result.Success = true
+		resp.SuccessCount++
+	}
+}
+
+// importRows 逐行读取导入的文件,读完后Next返回io.EOF
+type importRows interface {
+	Next() ([]string, error)
+	Close() error
+}
+
+type csvImportRows struct {
+	reader *csv.Reader
+}
+
+func (r *csvImportRows) Next() ([]string, error) {
+	return r.reader.Read()
+}
+
+func (r *csvImportRows) Close() error {
+	return nil
+}
+
+type xlsxImportRows struct {
+	f    *excelize.File
+	rows *excelize.Rows
+}
+
+func (r *xlsxImportRows) Next() ([]string, error) {
+	if !r.rows.Next() {
+		if err := r.rows.Error(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return r.rows.Columns()
+}
+
+func (r *xlsxImportRows) Close() error {
+	r.rows.Close()
+	return r.f.Close()
+}
+
+func openImportRows(file *ImportFile) (importRows, error) {
+	switch strings.ToLower(filepath.Ext(file.Name)) {
+	case ".csv":
+		br := bufio.NewReader(file.Reader)
+		// 跳过Excel保存的csv文件开头的BOM
+		if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+			br.Discard(3)
+		}
+		reader := csv.NewReader(br)
+		reader.FieldsPerRecord = -1
+		return &csvImportRows{reader: reader}, nil
+	case ".xlsx":
+		f, err := excelize.OpenReader(file.Reader)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := f.Rows(f.GetSheetName(0))
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &xlsxImportRows{f: f, rows: rows}, nil
+	default:
+		return nil, errors.New("只支持csv和xlsx文件")
+	}
+}
+
+// importColumns 根据表头找到对应的元数据字段,表头可以是页面字段的标题、元数据字段的显示名称或者字段名
+// 找不到对应字段的列会被忽略
+func importColumns(page *Page, header []string) ([]*importColumn, error) {
+	columns := make([]*importColumn, len(header))
+	found := false
+	for i, title := range header {
+		title = strings.TrimSpace(title)
+		column := &importColumn{}
+		for _, pf := range page.Fields {
+			if pf.Title == title || pf.Name == title {
+				column.Field = page.Metadata.FieldByName(pf.Name)
+				column.ValueEnum = reverseValueEnum(ParseValueEnum(pf.ValueEnum))
+				break
+			}
+		}
+		if column.Field == nil {
+			for _, field := range page.Metadata.MetadataFields {
+				if field.DisplayName == title || field.Name == title {
+					column.Field = field
+					break
+				}
+			}
+		}
+		if column.Field != nil && strings.EqualFold(column.Field.Name, "id") {
+			column.Field = nil
+		}
+		found = found || column.Field != nil
+		columns[i] = column
+	}
+	if !found {
+		return nil, errors.New("表头和页面字段都不匹配")
+	}
+	return columns, nil
+}
+
+func reverseValueEnum(enum map[string]string) map[string]string {
+	if len(enum) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(enum))
+	for value, label := range enum {
+		result[label] = value
+	}
+	return result
+}
+
+func importRowToData(columns []*importColumn, row []string) map[string]interface{} {
+	data := make(map[string]interface{})
+	for i, column := range columns {
+		if column.Field == nil || i >= len(row) {
+			continue
+		}
+		value := strings.TrimSpace(row[i])
+		if value == "" {
+			continue
+		}
+		if code, ok := column.ValueEnum[value]; ok {
+			value = code
+		}
+		data[column.Field.Name] = value
+	}
+	return data
+}
+
+func fieldTitle(field *MetadataField) string {
+	if field.DisplayName != "" {
+		return field.DisplayName
+	}
+	return field.Name
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestImport(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "import.db"), false), true)
+
+	md := &Metadata{Name: "ImportItem", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar", DisplayName: "名称", Unique: true}, {Name: "count", Type: "int"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "import_item", Title: "物料", Enable: true, Metadata: md,
+		ToolBar: &PageToolBar{ImportMulti: true, ImportMaxCount: 2}}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE import_items(id integer primary key autoincrement, name varchar(20), count int)").Error; err != nil {
+		t.Fatal(err)
+	}
+	// 新增后的钩子失败时这一行也要回滚
+	RegisterHook("import_item", HookAfterCreate, func(ctx *HookContext) error {
+		if ctx.Data["name"] == "bad" {
+			return AbortHook("不能导入%s", ctx.Data["name"])
+		}
+		return nil
+	})
+
+	f := excelize.NewFile()
+	f.SetSheetRow(f.GetSheetName(0), "A1", &[]interface{}{"名称", "count"})
+	f.SetSheetRow(f.GetSheetName(0), "A2", &[]interface{}{"pear", 3})
+	f.SetSheetRow(f.GetSheetName(0), "A4", &[]interface{}{"apple", 4})
+	xlsx, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := func() []*ImportFile {
+		return []*ImportFile{
+			{Name: "a.csv", Reader: strings.NewReader("\xEF\xBB\xBF名称,count\napple,1\nbad,2\n,\napple,3\n")},
+			{Name: "b.xlsx", Reader: bytes.NewReader(xlsx.Bytes())},
+		}
+	}
+
+	resp := &ImportResponse{}
+	Import(&ImportRequest{PageName: "import_item"}, append(files(), files()...), resp)
+	if resp.Code != 40000 || len(resp.Data) != 0 {
+		t.Fatalf("expected too many files, got %d %s", resp.Code, resp.Message)
+	}
+
+	resp = &ImportResponse{}
+	Import(&ImportRequest{PageName: "import_item"}, files(), resp)
+	if resp.Code != 0 || resp.SuccessCount != 2 || resp.FailureCount != 3 {
+		t.Fatalf("unexpected import result %d %s %d %d", resp.Code, resp.Message, resp.SuccessCount, resp.FailureCount)
+	}
+	var rows []string
+	for _, result := range resp.Data {
+		rows = append(rows, fmt.Sprintf("%s:%d:%v", result.File, result.Row, result.Success))
+	}
+	if got := strings.Join(rows, ","); got != "a.csv:2:true,a.csv:3:false,a.csv:5:false,b.xlsx:2:true,b.xlsx:4:false" {
+		t.Fatalf("unexpected rows %s", got)
+	}
+	var names []string
+	dbClient.DB().Table("import_items").Order("id").Pluck("name", &names)
+	if strings.Join(names, ",") != "apple,pear" {
+		t.Fatalf("unexpected records %v", names)
+	}
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CloudSilk/curd/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrNoKeywordField = errors.New("没有开启Like的字段,不能使用关键字查询")
+
+// fullTextCacheTTL 全文索引信息的缓存时间,新建索引后最多过这么久才会生效
+const fullTextCacheTTL = time.Minute
+
+// fullTextIndex 表上的全文索引,Columns为MySQL FULLTEXT索引的字段,FTS5为SQLite的FTS5表名
+type fullTextIndex struct {
+	Columns []string
+	FTS5    string
+	expires time.Time
+}
+
+// fullTextColumn information_schema.statistics中FULLTEXT索引的字段
+type fullTextColumn struct {
+	IndexName  string
+	ColumnName string
+}
+
+var fullTextIndexes sync.Map
+
+// ApplyKeyword 在所有开启了Like的字段中模糊查询keyword,字段之间是OR关系,和其他查询条件是AND关系。
+// 加密字段和当前用户不能查看明文的脱敏字段不参与查询,避免通过关键字猜测原始值。
+// 配置开启了fullTextSearch并且表上有全文索引时使用全文索引:
+// MySQL使用覆盖Like字段的FULLTEXT索引,SQLite使用名为<表名>_fts的FTS5表,FTS5表的rowid必须是记录的id,
+// 索引中的字段必须都是参与查询的字段
+func ApplyKeyword(db *gorm.DB, op *Operator, md *Metadata, keyword string) (*gorm.DB, error) {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return db, nil
+	}
+	var columns []string
+	var likes []clause.Expression
+	for _, field := range md.MetadataFields {
+		if !field.Like || field.Encrypt || (field.Mask != "" && !canUnmask(op, field)) || IsRelationField(md, field) {
+			continue
+		}
+		column := LowerSnakeCase(field.Name)
+		columns = append(columns, column)
+		likes = append(likes, likeExpression(clause.Column{Name: column}, keyword))
+	}
+	if len(likes) == 0 {
+		return nil, ErrNoKeywordField
+	}
+	if config.DefaultConfig.FullTextSearch {
+		index, err := getFullTextIndex(NamingStrategy.TableName(md.Name), columns)
+		if err != nil {
+			return nil, err
+		}
+		if len(index.Columns) > 0 {
+			return db.Where("MATCH ("+quoteColumns(index.Columns)+") AGAINST (?)", keyword), nil
+		}
+		if index.FTS5 != "" {
+			table := QuoteColumn(index.FTS5)
+			// 作为短语查询,避免关键字中的特殊字符被当成FTS5的查询语法
+			phrase := `"` + strings.ReplaceAll(keyword, `"`, `""`) + `"`
+			return db.Where(QuoteColumn("id")+" IN (SELECT rowid FROM "+table+" WHERE "+table+" MATCH ?)", phrase), nil
+		}
+	}
+	return db.Where(clause.Or(likes...)), nil
+}
+
+// getFullTextIndex 查找表上可以用于关键字查询的全文索引,结果会缓存fullTextCacheTTL
+func getFullTextIndex(table string, likeColumns []string) (*fullTextIndex, error) {
+	key := table + ":" + strings.Join(likeColumns, ",")
+	if v, ok := fullTextIndexes.Load(key); ok && v.(*fullTextIndex).expires.After(time.Now()) {
+		return v.(*fullTextIndex), nil
+	}
+	index := &fullTextIndex{expires: time.Now().Add(fullTextCacheTTL)}
+	db := dbClient.DB()
+	switch db.Dialector.Name() {
+	case "mysql":
+		var rows []fullTextColumn
+		err := db.Raw("SELECT index_name AS index_name, column_name AS column_name FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_type = 'FULLTEXT' ORDER BY index_name, seq_in_index", table).Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		index.Columns = mysqlFullTextColumns(rows, likeColumns)
+	case "sqlite":
+		var count int64
+		name := table + "_fts"
+		err := db.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count).Error
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			var columns []string
+			if err = db.Raw("SELECT name FROM pragma_table_info(?)", name).Scan(&columns).Error; err != nil {
+				return nil, err
+			}
+			if coveredColumns(columns, likeColumns) {
+				index.FTS5 = name
+			}
+		}
+	}
+	fullTextIndexes.Store(key, index)
+	return index, nil
+}
+
+// coveredColumns 索引中的字段是否都是参与查询的字段
+func coveredColumns(columns, likeColumns []string) bool {
+	like := make(map[string]bool)
+	for _, column := range likeColumns {
+		like[column] = true
+	}
+	for _, column := range columns {
+		if !like[column] {
+			return false
+		}
+	}
+	return len(columns) > 0
+}
+
+// mysqlFullTextColumns 返回字段都开启了Like的第一个FULLTEXT索引的字段
+func mysqlFullTextColumns(rows []fullTextColumn, likeColumns []string) []string {
+	indexes := make(map[string][]string)
+	var names []string
+	for _, row := range rows {
+		if _, ok := indexes[row.IndexName]; !ok {
+			names = append(names, row.IndexName)
+		}
+		indexes[row.IndexName] = append(indexes[row.IndexName], row.ColumnName)
+	}
+	for _, name := range names {
+		if coveredColumns(indexes[name], likeColumns) {
+			return indexes[name]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestMysqlFullTextColumns(t *testing.T) {
+	rows := []fullTextColumn{
+		{IndexName: "ft_all", ColumnName: "name"},
+		{IndexName: "ft_all", ColumnName: "content"},
+		{IndexName: "ft_name", ColumnName: "name"},
+		{IndexName: "ft_name", ColumnName: "remark"},
+	}
+	if columns := mysqlFullTextColumns(rows, []string{"name", "remark"}); !reflect.DeepEqual(columns, []string{"name", "remark"}) {
+		t.Fatalf("expected index ft_name, got %v", columns)
+	}
+	if columns := mysqlFullTextColumns(rows, []string{"name"}); columns != nil {
+		t.Fatalf("index with non like column should not be used, got %v", columns)
+	}
+}
+
+func TestApplyKeywordWithoutLikeField(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "name"}}}
+	if _, err := ApplyKeyword(nil, nil, md, "a"); err != ErrNoKeywordField {
+		t.Fatalf("expected ErrNoKeywordField, got %v", err)
+	}
+}
+
+func TestKeywordRecords(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "keyword.db"), false), true)
+
+	md := &Metadata{Name: "Member", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar", Like: true},
+		{Name: "phone", Type: "varchar", Like: true, Mask: "phone", UnmaskRoles: "admin"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "member", Title: "会员", Enable: true, Metadata: md}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE members(id integer primary key autoincrement, name varchar(20), phone varchar(20))").Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []map[string]interface{}{{"name": "tom", "phone": "13812341234"}, {"name": "50%_off", "phone": "13900000000"}} {
+		if err = Create(nil, "member", m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	staff, admin := &Operator{RoleIDs: []string{"staff"}}, &Operator{RoleIDs: []string{"admin"}}
+	for _, c := range []struct {
+		op      *Operator
+		keyword string
+		total   int64
+	}{
+		// 不能查看明文的用户不能通过关键字查询脱敏字段
+		{staff, "1234", 0},
+		{admin, "1234", 1},
+		{staff, "_o", 1},
+		{staff, "%", 1},
+	} {
+		resp := &QueryResponse{}
+		Query(&QueryRequest{PageName: "member", Operator: c.op, Keyword: c.keyword}, resp)
+		if resp.Code != 0 || resp.Total != c.total {
+			t.Errorf("%s: unexpected query %d %s %v", c.keyword, resp.Code, resp.Message, resp.Data)
+		}
+	}
+}
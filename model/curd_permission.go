@@ -0,0 +1,189 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// FieldPermissionHidden 不能查看和修改
+	FieldPermissionHidden = "hidden"
+	// FieldPermissionReadOnly 只能查看
+	FieldPermissionReadOnly = "readonly"
+	// FieldPermissionReadWrite 可以查看和修改,没有配置权限的字段默认是readwrite
+	FieldPermissionReadWrite = "readwrite"
+)
+
+// fieldPermissionLevels 数字越大权限越高,用户有多个角色时取最高的权限
+var fieldPermissionLevels = map[string]int{
+	FieldPermissionHidden:    0,
+	FieldPermissionReadOnly:  1,
+	FieldPermissionReadWrite: 2,
+}
+
+// CheckPageFieldPermissions 保存页面配置时检查字段权限,同一个角色的同一个字段只能配置一次
+func CheckPageFieldPermissions(permissions []*PageFieldPermission) error {
+	exists := make(map[string]bool)
+	for _, permission := range permissions {
+		if permission.Field == "" {
+			return fmt.Errorf("字段权限的字段不能为空")
+		}
+		if strings.EqualFold(permission.Field, "id") {
+			return fmt.Errorf("不能设置id字段的权限")
+		}
+		if _, ok := fieldPermissionLevels[permission.Permission]; !ok {
+			return fmt.Errorf("字段%s的权限%s不支持,只能是hidden、readonly或者readwrite", permission.Field, permission.Permission)
+		}
+		key := permission.RoleID + "." + LowerSnakeCase(permission.Field)
+		if exists[key] {
+			return fmt.Errorf("角色%s的字段%s重复配置了权限", permission.RoleID, permission.Field)
+		}
+		exists[key] = true
+	}
+	return nil
+}
+
+// fieldPermissions 返回当前用户受限制的字段,key是元数据字段名,值是hidden或者readonly。
+// 角色为空的配置是默认权限,角色单独配置的权限优先;用户有多个角色时取最高的权限。
+// op为nil时没有用户信息,每个字段取所有配置中最低的权限;只有SystemOperator不限制字段权限
+func fieldPermissions(op *Operator, page *Page) map[string]string {
+	result := make(map[string]string)
+	if (op != nil && op.system) || len(page.FieldPermissions) == 0 {
+		return result
+	}
+	defaults := make(map[string]string)
+	roles := make(map[string]map[string]string)
+	for _, permission := range page.FieldPermissions {
+		field := page.Metadata.FieldByName(permission.Field)
+		if field == nil || strings.EqualFold(field.Name, "id") {
+			continue
+		}
+		if permission.RoleID == "" {
+			defaults[field.Name] = permission.Permission
+			continue
+		}
+		if roles[permission.RoleID] == nil {
+			roles[permission.RoleID] = make(map[string]string)
+		}
+		roles[permission.RoleID][field.Name] = permission.Permission
+	}
+
+	if op == nil {
+		for _, permission := range page.FieldPermissions {
+			field := page.Metadata.FieldByName(permission.Field)
+			if field == nil || strings.EqualFold(field.Name, "id") || permission.Permission == FieldPermissionReadWrite {
+				continue
+			}
+			if p, ok := result[field.Name]; !ok || fieldPermissionLevels[permission.Permission] < fieldPermissionLevels[p] {
+				result[field.Name] = permission.Permission
+			}
+		}
+		return result
+	}
+
+	for _, field := range page.Metadata.MetadataFields {
+		permission := ""
+		if len(op.RoleIDs) == 0 {
+			permission = defaults[field.Name]
+		}
+		for _, roleID := range op.RoleIDs {
+			p, ok := roles[roleID][field.Name]
+			if !ok {
+				p = defaults[field.Name]
+			}
+			if p == "" || p == FieldPermissionReadWrite {
+				permission = ""
+				break
+			}
+			if permission == "" || fieldPermissionLevels[p] > fieldPermissionLevels[permission] {
+				permission = p
+			}
+		}
+		if permission != "" && permission != FieldPermissionReadWrite {
+			result[field.Name] = permission
+		}
+	}
+	return result
+}
+
+// metadataFieldPermissions 没有页面时按照所有使用这个元数据的页面的字段权限处理,例如展开引用的记录,
+// 任何一个页面中隐藏的字段都不返回
+func metadataFieldPermissions(op *Operator, md *Metadata) (map[string]string, error) {
+	pages, err := metadataPages(md)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string)
+	for _, page := range pages {
+		for name, permission := range fieldPermissions(op, page) {
+			if permission == FieldPermissionHidden {
+				result[name] = permission
+			}
+		}
+	}
+	return result, nil
+}
+
+// FieldAccess 返回当前用户每个字段生效的权限,前端根据权限隐藏列或者输入框
+func FieldAccess(op *Operator, page *Page) map[string]string {
+	permissions := fieldPermissions(op, page)
+	access := make(map[string]string)
+	if page.Metadata == nil {
+		return access
+	}
+	for _, field := range page.Metadata.MetadataFields {
+		access[field.Name] = FieldPermissionReadWrite
+		if permission, ok := permissions[field.Name]; ok {
+			access[field.Name] = permission
+		}
+	}
+	return access
+}
+
+// readableMetadata 返回去掉隐藏字段后的元数据,用于查询条件、排序、关键字和统计,
+// 避免通过查询条件推测出隐藏字段的值
+func readableMetadata(md *Metadata, permissions map[string]string) *Metadata {
+	var fields []*MetadataField
+	for _, field := range md.MetadataFields {
+		if permissions[field.Name] != FieldPermissionHidden {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == len(md.MetadataFields) {
+		return md
+	}
+	readable := *md
+	readable.MetadataFields = fields
+	return &readable
+}
+
+// stripHiddenFields 删除记录中的隐藏字段,记录的key是驼峰形式的列名
+func stripHiddenFields(permissions map[string]string, records ...map[string]interface{}) {
+	for name, permission := range permissions {
+		if permission != FieldPermissionHidden {
+			continue
+		}
+		key := CamelName2(LowerSnakeCase(name))
+		for _, record := range records {
+			delete(record, key)
+		}
+	}
+}
+
+// readOnlyError 修改没有写权限的字段时返回的错误
+func readOnlyError(md *Metadata, permissions map[string]string, data map[string]interface{}) error {
+	errs := ValidationErrors{}
+	for key := range data {
+		field := md.FieldByName(key)
+		if field == nil {
+			continue
+		}
+		if _, ok := permissions[field.Name]; ok {
+			errs[field.Name] = "没有权限修改" + fieldTitle(field)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestFieldPermissions(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "id"}, {Name: "name"}, {Name: "salary"}, {Name: "costPrice"}}}
+	page := &Page{Metadata: md, FieldPermissions: []*PageFieldPermission{
+		{Field: "salary", Permission: FieldPermissionHidden},
+		{Field: "cost_price", Permission: FieldPermissionReadOnly},
+		{RoleID: "hr", Field: "salary", Permission: FieldPermissionReadWrite},
+		{RoleID: "sales", Field: "costPrice", Permission: FieldPermissionHidden},
+		{RoleID: "auditor", Field: "salary", Permission: FieldPermissionReadOnly},
+		{RoleID: "auditor", Field: "costPrice", Permission: FieldPermissionReadOnly},
+	}}
+	for _, c := range []struct {
+		op   *Operator
+		want map[string]string
+	}{
+		// 没有用户信息时取最低的权限,只有系统调用不限制
+		{nil, map[string]string{"salary": FieldPermissionHidden, "costPrice": FieldPermissionHidden}},
+		{SystemOperator(), map[string]string{}},
+		{&Operator{}, map[string]string{"salary": FieldPermissionHidden, "costPrice": FieldPermissionReadOnly}},
+		{&Operator{RoleIDs: []string{"hr"}}, map[string]string{"costPrice": FieldPermissionReadOnly}},
+		{&Operator{RoleIDs: []string{"sales"}}, map[string]string{"salary": FieldPermissionHidden, "costPrice": FieldPermissionHidden}},
+		// 多个角色取最高的权限
+		{&Operator{RoleIDs: []string{"sales", "auditor"}}, map[string]string{"salary": FieldPermissionReadOnly, "costPrice": FieldPermissionReadOnly}},
+		{&Operator{RoleIDs: []string{"sales", "hr"}}, map[string]string{"costPrice": FieldPermissionReadOnly}},
+	} {
+		if got := fieldPermissions(c.op, page); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%+v: expected %v, got %v", c.op, c.want, got)
+		}
+	}
+
+	access := FieldAccess(&Operator{RoleIDs: []string{"sales"}}, page)
+	want := map[string]string{"id": FieldPermissionReadWrite, "name": FieldPermissionReadWrite, "salary": FieldPermissionHidden, "costPrice": FieldPermissionHidden}
+	if !reflect.DeepEqual(access, want) {
+		t.Errorf("expected %v, got %v", want, access)
+	}
+}
+
+func TestCheckPageFieldPermissions(t *testing.T) {
+	for _, c := range []struct {
+		permissions []*PageFieldPermission
+		valid       bool
+	}{
+		{[]*PageFieldPermission{{Field: "salary", Permission: FieldPermissionHidden}, {RoleID: "hr", Field: "salary", Permission: FieldPermissionReadWrite}}, true},
+		{[]*PageFieldPermission{{Field: "salary", Permission: "write"}}, false},
+		{[]*PageFieldPermission{{Field: "", Permission: FieldPermissionHidden}}, false},
+		{[]*PageFieldPermission{{Field: "id", Permission: FieldPermissionHidden}}, false},
+		{[]*PageFieldPermission{{Field: "costPrice", Permission: FieldPermissionHidden}, {Field: "cost_price", Permission: FieldPermissionReadOnly}}, false},
+	} {
+		if err := CheckPageFieldPermissions(c.permissions); (err == nil) != c.valid {
+			t.Errorf("%+v: unexpected result %v", c.permissions, err)
+		}
+	}
+}
+
+func TestFieldPermissionRecords(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "permission.db"), false), true)
+
+	md := &Metadata{Name: "Employee", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}, {Name: "salary", Type: "int", ShowInQuery: true}, {Name: "level", Type: "int", DefaultValue: "1"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "employee", Title: "员工", Enable: true, Metadata: md, FieldPermissions: []*PageFieldPermission{
+		{Field: "salary", Permission: FieldPermissionHidden},
+		{Field: "level", Permission: FieldPermissionReadOnly},
+		{RoleID: "hr", Field: "salary", Permission: FieldPermissionReadWrite},
+	}}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE employees(id integer primary key autoincrement, name varchar(20), salary int, level int)").Error; err != nil {
+		t.Fatal(err)
+	}
+	hr := &Operator{UserID: "hr", RoleIDs: []string{"hr"}}
+	staff := &Operator{UserID: "staff", RoleIDs: []string{"staff"}}
+	if err = Create(SystemOperator(), "employee", map[string]interface{}{"name": "tom", "salary": 100, "level": 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := GetDetailById(staff, "employee", "1", "", "")
+	if err != nil || data["name"] != "tom" || data["level"] == nil {
+		t.Fatalf("unexpected detail %v %v", data, err)
+	}
+	if _, ok := data["salary"]; ok {
+		t.Fatalf("salary should be hidden: %v", data)
+	}
+	resp := &QueryResponse{}
+	Query(&QueryRequest{PageName: "employee", Operator: staff}, resp)
+	if _, ok := resp.Data[0]["salary"]; resp.Code != 0 || ok {
+		t.Fatalf("unexpected query %d %s %v", resp.Code, resp.Message, resp.Data)
+	}
+	// 不能使用隐藏字段作为查询条件
+	resp = &QueryResponse{}
+	Query(&QueryRequest{PageName: "employee", Operator: staff, Data: map[string]interface{}{"salary": 100}}, resp)
+	if resp.Code != 40000 {
+		t.Fatalf("expected bad request, got %d %s", resp.Code, resp.Message)
+	}
+	resp = &QueryResponse{}
+	Query(&QueryRequest{PageName: "employee", Operator: hr, Data: map[string]interface{}{"salary": 100}}, resp)
+	if resp.Code != 0 || resp.Total != 1 || toInt(resp.Data[0]["salary"]) != 100 {
+		t.Fatalf("unexpected query %d %s %v", resp.Code, resp.Message, resp.Data)
+	}
+
+	// 没有写权限的字段在新增和修改时忽略,局部更新时返回错误
+	if err = Update(staff, "employee", map[string]interface{}{"id": 1, "name": "tom2", "level": 9}); err != nil {
+		t.Fatal(err)
+	}
+	if err = Patch(staff, "employee", map[string]interface{}{"id": 1, "level": 9}); err == nil {
+		t.Fatal("expected read only error")
+	}
+	if err = Create(staff, "employee", map[string]interface{}{"name": "jerry", "salary": 200, "level": 9}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := GetAll(hr, "employee")
+	if err != nil || len(list) != 2 {
+		t.Fatalf("unexpected records %v %v", list, err)
+	}
+	if list[0]["name"] != "tom2" || toInt(list[0]["salary"]) != 100 || toInt(list[0]["level"]) != 3 {
+		t.Fatalf("unexpected record %v", list[0])
+	}
+	if list[1]["salary"] != nil || toInt(list[1]["level"]) != 1 {
+		t.Fatalf("unexpected record %v", list[1])
+	}
+
+	// 展开引用的记录时按照被引用页面的字段权限去掉隐藏字段
+	slip := &Metadata{Name: "Payslip", MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}, {Name: "employee", Type: "bigint", RefMetadata: md.ID}}}
+	if err = dbClient.DB().Create(&Page{Name: "payslip", Title: "工资单", Enable: true, Metadata: slip}).Error; err != nil {
+		t.Fatal(err)
+	}
+	dbClient.DB().Exec("CREATE TABLE payslips(id integer primary key autoincrement, employee bigint)")
+	dbClient.DB().Exec("INSERT INTO payslips(employee) VALUES (1)")
+	for _, c := range []struct {
+		op     *Operator
+		salary bool
+	}{{staff, false}, {hr, true}} {
+		data, err := GetDetailById(c.op, "payslip", "1", "employee", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		employee, ok := data["employee"].(map[string]interface{})
+		if !ok || employee["name"] != "tom2" {
+			t.Fatalf("%s: unexpected detail %v", c.op.UserID, data)
+		}
+		if _, ok = employee["salary"]; ok != c.salary {
+			t.Fatalf("%s: unexpected salary in %v", c.op.UserID, employee)
+		}
+	}
+}
+
+func toInt(value interface{}) int64 {
+	i, _ := toInt64(derefValue(value))
+	n, _ := i.(int64)
+	return n
+}
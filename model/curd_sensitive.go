@@ -0,0 +1,416 @@
+package model
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/CloudSilk/curd/config"
+)
+
+// encryptedPrefix 加密后的值的前缀,没有前缀的值是开启加密前写入的明文
+const encryptedPrefix = "enc:"
+
+const (
+	// gcmNonceSize和gcmTagSize是AES-GCM默认的nonce和认证标签的字节数
+	gcmNonceSize = 12
+	gcmTagSize   = 16
+	// BlindIndexLength 盲索引是HMAC-SHA256的十六进制字符串
+	BlindIndexLength = 64
+)
+
+// EncryptedLength 返回n个字节的明文加密后的长度,包括enc:前缀和base64编码的nonce、密文和认证标签
+func EncryptedLength(n int) int {
+	return len(encryptedPrefix) + base64.StdEncoding.EncodedLen(gcmNonceSize+n+gcmTagSize)
+}
+
+var ErrNoEncryptionKey = errors.New("没有配置加密密钥encryption.key")
+
+// MaskRules 预置的脱敏规则,值是前面和后面保留的字符数
+var MaskRules = map[string][2]int{
+	"phone":    {3, 4},
+	"idCard":   {6, 4},
+	"bankCard": {4, 4},
+	"name":     {1, 0},
+}
+
+func encryptionKey() ([]byte, error) {
+	if config.DefaultConfig.Encryption.Key == "" {
+		return nil, ErrNoEncryptionKey
+	}
+	key, err := base64.StdEncoding.DecodeString(config.DefaultConfig.Encryption.Key)
+	if err != nil {
+		return nil, fmt.Errorf("加密密钥必须是base64编码:%w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	}
+	return nil, errors.New("加密密钥的长度必须是16、24或者32字节")
+}
+
+func newGCM() (cipher.AEAD, error) {
+	key, err := encryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptValue 使用AES-GCM加密,结果是enc:前缀加上base64编码的nonce和密文
+func EncryptValue(value string) (string, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(value), nil)), nil
+}
+
+// DecryptValue 解密EncryptValue的结果,没有enc:前缀的值直接返回
+func DecryptValue(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedPrefix) {
+		return value, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("解密失败:%w", err)
+	}
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("解密失败:密文长度错误")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("解密失败:%w", err)
+	}
+	return string(plain), nil
+}
+
+// BlindIndex 使用HMAC-SHA256计算盲索引,相同的明文得到相同的结果,用于精确查询和唯一性检查
+func BlindIndex(value string) (string, error) {
+	key := []byte(config.DefaultConfig.Encryption.BlindIndexKey)
+	if len(key) == 0 {
+		encryption, err := encryptionKey()
+		if err != nil {
+			return "", err
+		}
+		mac := hmac.New(sha256.New, encryption)
+		mac.Write([]byte("curd-blind-index"))
+		key = mac.Sum(nil)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.TrimSpace(value)))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func parseMaskRule(rule string) (int, int, error) {
+	if r, ok := MaskRules[rule]; ok {
+		return r[0], r[1], nil
+	}
+	parts := strings.Split(rule, ",")
+	if len(parts) == 2 {
+		prefix, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+		suffix, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err1 == nil && err2 == nil && prefix >= 0 && suffix >= 0 {
+			return prefix, suffix, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("脱敏规则%s格式错误", rule)
+}
+
+// MaskValue 按照规则把中间的字符替换成*,例如规则3,4把13812341234脱敏成138****1234,
+// 长度不超过保留位数时全部替换
+func MaskValue(rule, value string) (string, error) {
+	prefix, suffix, err := parseMaskRule(rule)
+	if err != nil {
+		return "", err
+	}
+	runes := []rune(value)
+	if len(runes) <= prefix+suffix {
+		return strings.Repeat("*", len(runes)), nil
+	}
+	return string(runes[:prefix]) + strings.Repeat("*", len(runes)-prefix-suffix) + string(runes[len(runes)-suffix:]), nil
+}
+
+// CheckSensitiveFields 保存元数据时检查加密和脱敏配置
+func CheckSensitiveFields(md *Metadata) error {
+	for _, field := range md.MetadataFields {
+		if field.Mask != "" {
+			if _, _, err := parseMaskRule(field.Mask); err != nil {
+				return fmt.Errorf("字段%s的%w", field.Name, err)
+			}
+		}
+		if !field.Encrypt {
+			if field.BlindIndexField != "" {
+				return fmt.Errorf("字段%s没有加密,不需要盲索引", field.Name)
+			}
+			continue
+		}
+		if !isStringType(field.Type) {
+			return fmt.Errorf("加密字段%s必须是字符串类型", field.Name)
+		}
+		if field.Like {
+			return fmt.Errorf("加密字段%s不支持like查询", field.Name)
+		}
+		// 字段长度是数据库中保存密文的长度,0表示不限制
+		if field.Length > 0 && int(field.Length) < EncryptedLength(1) {
+			return fmt.Errorf("加密字段%s保存的是密文,长度至少为%d", field.Name, EncryptedLength(1))
+		}
+		if field.BlindIndexField == "" {
+			// 加密后相同的明文得到不同的密文,只能使用盲索引检查唯一性
+			if field.Unique {
+				return fmt.Errorf("唯一的加密字段%s必须设置盲索引字段", field.Name)
+			}
+			continue
+		}
+		index := md.FieldByName(field.BlindIndexField)
+		if index == nil || index == field || index.Encrypt || !isStringType(index.Type) {
+			return fmt.Errorf("字段%s的盲索引字段%s必须是没有加密的字符串字段", field.Name, field.BlindIndexField)
+		}
+		if index.Length > 0 && index.Length < BlindIndexLength {
+			return fmt.Errorf("盲索引字段%s的长度至少为%d", index.Name, BlindIndexLength)
+		}
+	}
+	return nil
+}
+
+func isStringType(t string) bool {
+	switch t {
+	case "varchar", "nvarchar", "string", "longtext", "nvarchar(max)", "text":
+		return true
+	}
+	return false
+}
+
+func blindIndexField(md *Metadata, field *MetadataField) *MetadataField {
+	if !field.Encrypt || field.BlindIndexField == "" {
+		return nil
+	}
+	return md.FieldByName(field.BlindIndexField)
+}
+
+// uniqueField 加密字段使用盲索引检查唯一性,加密字段没有盲索引时无法检查
+func uniqueField(md *Metadata, field *MetadataField) (*MetadataField, error) {
+	if index := blindIndexField(md, field); index != nil {
+		return index, nil
+	}
+	if field.Encrypt {
+		return nil, fmt.Errorf("唯一的加密字段%s没有盲索引,不能检查是否重复", field.Name)
+	}
+	return field, nil
+}
+
+func stringValue(value interface{}) (string, bool) {
+	value = derefValue(value)
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return fmt.Sprint(value), true
+}
+
+// plainValue 从数据库读取的加密字段的值解密成明文
+func plainValue(field *MetadataField, value interface{}) (interface{}, error) {
+	if !field.Encrypt {
+		return value, nil
+	}
+	str, ok := stringValue(value)
+	if !ok {
+		return nil, nil
+	}
+	return DecryptValue(str)
+}
+
+// canUnmask 当前用户是否可以查看脱敏字段的原值,op为nil时没有用户信息不能查看,SystemOperator可以查看
+func canUnmask(op *Operator, field *MetadataField) bool {
+	if op == nil {
+		return false
+	}
+	if op.system {
+		return true
+	}
+	for _, roleID := range strings.Split(field.UnmaskRoles, ",") {
+		roleID = strings.TrimSpace(roleID)
+		for _, id := range op.RoleIDs {
+			if roleID != "" && roleID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// revealValue 解密并且按照当前用户的权限脱敏
+func revealValue(op *Operator, field *MetadataField, value interface{}) (interface{}, error) {
+	value, err := plainValue(field, value)
+	if err != nil || field.Mask == "" || canUnmask(op, field) {
+		return value, err
+	}
+	str, ok := stringValue(value)
+	if !ok {
+		return nil, nil
+	}
+	return MaskValue(field.Mask, str)
+}
+
+// revealRecords 返回记录前解密和脱敏,盲索引只用于查询,不返回。记录的key是驼峰形式的列名
+func revealRecords(op *Operator, md *Metadata, records ...map[string]interface{}) error {
+	for _, field := range md.MetadataFields {
+		if index := blindIndexField(md, field); index != nil {
+			key := CamelName2(LowerSnakeCase(index.Name))
+			for _, record := range records {
+				delete(record, key)
+			}
+		}
+		if !field.Encrypt && field.Mask == "" {
+			continue
+		}
+		key := CamelName2(LowerSnakeCase(field.Name))
+		for _, record := range records {
+			value, ok := record[key]
+			if !ok {
+				continue
+			}
+			value, err := revealValue(op, field, value)
+			if err != nil {
+				return fmt.Errorf("字段%s%w", field.Name, err)
+			}
+			record[key] = value
+		}
+	}
+	return nil
+}
+
+// keepMaskedValues 用户不能查看明文时,提交的脱敏后的值和原来的值脱敏后一样,说明没有修改,使用原来的值。
+// current是数据库中的记录,key是列名
+func keepMaskedValues(op *Operator, md *Metadata, m, current map[string]interface{}) error {
+	for _, field := range md.MetadataFields {
+		if field.Mask == "" || canUnmask(op, field) {
+			continue
+		}
+		submitted, ok := m[field.Name].(string)
+		if !ok {
+			continue
+		}
+		value, err := plainValue(field, current[LowerSnakeCase(field.Name)])
+		if err != nil {
+			return err
+		}
+		str, ok := stringValue(value)
+		if !ok {
+			continue
+		}
+		masked, err := MaskValue(field.Mask, str)
+		if err != nil {
+			return err
+		}
+		if submitted == masked {
+			m[field.Name] = str
+		}
+	}
+	return nil
+}
+
+// setBlindIndexes 根据m中加密字段的明文计算盲索引,m的key是字段名
+func setBlindIndexes(md *Metadata, m map[string]interface{}) error {
+	for _, field := range md.MetadataFields {
+		index := blindIndexField(md, field)
+		if index == nil {
+			continue
+		}
+		value, ok := m[field.Name]
+		if !ok {
+			continue
+		}
+		str, ok := stringValue(value)
+		if !ok || str == "" {
+			m[index.Name] = nil
+			continue
+		}
+		hash, err := BlindIndex(str)
+		if err != nil {
+			return err
+		}
+		m[index.Name] = hash
+	}
+	return nil
+}
+
+// encryptColumns 写入数据库前加密,values的key是列名
+func encryptColumns(md *Metadata, values map[string]interface{}) error {
+	for _, field := range md.MetadataFields {
+		if !field.Encrypt {
+			continue
+		}
+		column := LowerSnakeCase(field.Name)
+		str, ok := stringValue(values[column])
+		if !ok || str == "" {
+			continue
+		}
+		encrypted, err := EncryptValue(str)
+		if err != nil {
+			return err
+		}
+		values[column] = encrypted
+	}
+	return nil
+}
+
+// encryptedFilter 加密字段只支持通过盲索引精确查询
+func encryptedFilter(md *Metadata, f *Filter) (*Filter, error) {
+	switch f.Operator {
+	case FilterIsNull:
+		return f, nil
+	case FilterEq, FilterNe, FilterIn, FilterNotIn:
+	default:
+		return nil, fmt.Errorf("加密字段%s只支持精确查询", f.Field.Name)
+	}
+	index := blindIndexField(md, f.Field)
+	if index == nil {
+		return nil, fmt.Errorf("加密字段%s没有配置盲索引,不支持查询", f.Field.Name)
+	}
+	hash := func(value interface{}) (interface{}, error) {
+		str, _ := stringValue(value)
+		return BlindIndex(str)
+	}
+	if list, ok := f.Value.([]interface{}); ok {
+		values := make([]interface{}, len(list))
+		for i, value := range list {
+			h, err := hash(value)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = h
+		}
+		return &Filter{Field: index, Operator: f.Operator, Value: values}, nil
+	}
+	h, err := hash(f.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{Field: index, Operator: f.Operator, Value: h}, nil
+}
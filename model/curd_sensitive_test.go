@@ -0,0 +1,301 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CloudSilk/curd/config"
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+	pkgmodel "github.com/CloudSilk/pkg/model"
+)
+
+func setEncryptionKey(t *testing.T) {
+	old := config.DefaultConfig.Encryption
+	t.Cleanup(func() { config.DefaultConfig.Encryption = old })
+	config.DefaultConfig.Encryption.Key = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	config.DefaultConfig.Encryption.BlindIndexKey = ""
+}
+
+func TestMaskValue(t *testing.T) {
+	for _, c := range []struct {
+		rule, value, want string
+	}{
+		{"phone", "13812341234", "138****1234"},
+		{"3,4", "13812341234", "138****1234"},
+		{"idCard", "110101199001011234", "110101********1234"},
+		{"name", "张三丰", "张**"},
+		{"3,4", "1234", "****"},
+		{"0,0", "abc", "***"},
+	} {
+		if got, err := MaskValue(c.rule, c.value); err != nil || got != c.want {
+			t.Errorf("%s %s: expected %s, got %s %v", c.rule, c.value, c.want, got, err)
+		}
+	}
+	for _, rule := range []string{"", "3", "a,4", "-1,2", "email"} {
+		if _, err := MaskValue(rule, "13812341234"); err == nil {
+			t.Errorf("%s: expected error", rule)
+		}
+	}
+}
+
+func TestEncryptValue(t *testing.T) {
+	setEncryptionKey(t)
+	a, err := EncryptValue("13812341234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := EncryptValue("13812341234")
+	if a == b || !strings.HasPrefix(a, encryptedPrefix) || strings.Contains(a, "1381234") {
+		t.Fatalf("unexpected ciphertext %s %s", a, b)
+	}
+	if plain, err := DecryptValue(a); err != nil || plain != "13812341234" {
+		t.Fatalf("unexpected plaintext %s %v", plain, err)
+	}
+	// 开启加密前写入的明文直接返回
+	if plain, err := DecryptValue("13812341234"); err != nil || plain != "13812341234" {
+		t.Fatalf("unexpected plaintext %s %v", plain, err)
+	}
+	x, _ := BlindIndex("13812341234")
+	y, _ := BlindIndex(" 13812341234 ")
+	z, _ := BlindIndex("13812341235")
+	if x != y || x == z || len(x) != 64 {
+		t.Fatalf("unexpected blind index %s %s %s", x, y, z)
+	}
+
+	config.DefaultConfig.Encryption.Key = base64.StdEncoding.EncodeToString([]byte("fedcba9876543210"))
+	if _, err = DecryptValue(a); err == nil {
+		t.Fatal("expected error with wrong key")
+	}
+	config.DefaultConfig.Encryption.Key = ""
+	if _, err = EncryptValue("1"); !errors.Is(err, ErrNoEncryptionKey) {
+		t.Fatalf("expected ErrNoEncryptionKey, got %v", err)
+	}
+}
+
+func TestCheckSensitiveFields(t *testing.T) {
+	for _, c := range []struct {
+		fields []*MetadataField
+		valid  bool
+	}{
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, BlindIndexField: "phoneIndex", Mask: "phone"}, {Name: "phoneIndex", Type: "varchar"}}, true},
+		{[]*MetadataField{{Name: "age", Type: "int", Encrypt: true}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, Like: true}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, BlindIndexField: "phone"}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, BlindIndexField: "phoneIndex"}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", BlindIndexField: "phoneIndex"}, {Name: "phoneIndex", Type: "varchar"}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Mask: "4"}}, false},
+		// 唯一的加密字段需要盲索引,字段长度需要能保存密文和盲索引
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, Unique: true}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Length: 11, Encrypt: true}}, false},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Length: 64, Encrypt: true, Unique: true, BlindIndexField: "phoneIndex"}, {Name: "phoneIndex", Type: "varchar", Length: 64}}, true},
+		{[]*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, BlindIndexField: "phoneIndex"}, {Name: "phoneIndex", Type: "varchar", Length: 32}}, false},
+	} {
+		if err := CheckSensitiveFields(&Metadata{MetadataFields: c.fields}); (err == nil) != c.valid {
+			t.Errorf("%+v: unexpected result %v", c.fields[0], err)
+		}
+	}
+	// 保存前已经存在的元数据在检查唯一性时同样返回错误
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "phone", Type: "varchar", Encrypt: true, Unique: true}}}
+	if _, err := uniqueField(md, md.MetadataFields[0]); err == nil {
+		t.Fatal("expected error for unique encrypted field without blind index")
+	}
+}
+
+func TestEncryptedLength(t *testing.T) {
+	setEncryptionKey(t)
+	for _, value := range []string{"", "13812341234", "张三丰"} {
+		encrypted, err := EncryptValue(value)
+		if err != nil || len(encrypted) != EncryptedLength(len(value)) {
+			t.Errorf("%s: expected length %d, got %d %v", value, EncryptedLength(len(value)), len(encrypted), err)
+		}
+	}
+	field := &MetadataField{Name: "phone", Type: "varchar", Length: int32(EncryptedLength(11)), Encrypt: true}
+	if _, err := ConvertFieldValue(field, "13812341234"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ConvertFieldValue(field, "138123412345"); err == nil {
+		t.Fatal("expected length error")
+	}
+}
+
+func TestSensitiveRecords(t *testing.T) {
+	setEncryptionKey(t)
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "sensitive.db"), false), true)
+
+	md := &Metadata{Name: "Customer", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"},
+		{Name: "phone", Type: "varchar", Length: 200, Unique: true, ShowInQuery: true, Encrypt: true, BlindIndexField: "phoneIndex", Mask: "phone", UnmaskRoles: "admin"},
+		{Name: "phoneIndex", Type: "varchar"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "customer", Title: "客户", Enable: true, EnableAudit: true, Metadata: md}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE customers(id integer primary key autoincrement, name varchar(20), phone varchar(200), phone_index varchar(64))").Error; err != nil {
+		t.Fatal(err)
+	}
+	admin := &Operator{RoleIDs: []string{"admin"}}
+	staff := &Operator{RoleIDs: []string{"staff"}}
+	if err = Create(staff, "customer", map[string]interface{}{"name": "tom", "phone": "13812341234"}); err != nil {
+		t.Fatal(err)
+	}
+	raw := make(map[string]interface{})
+	dbClient.DB().Table("customers").Where("id = 1").Take(&raw)
+	if phone, _ := stringValue(raw["phone"]); !strings.HasPrefix(phone, encryptedPrefix) || raw["phone_index"] == nil {
+		t.Fatalf("phone should be encrypted: %v", raw)
+	}
+
+	data, err := GetDetailById(admin, "customer", "1", "", "")
+	if err != nil || data["phone"] != "13812341234" {
+		t.Fatalf("unexpected detail %v %v", data, err)
+	}
+	if _, ok := data["phoneIndex"]; ok {
+		t.Fatalf("blind index should not be returned: %v", data)
+	}
+	data, err = GetDetailById(staff, "customer", "1", "", "")
+	if err != nil || data["phone"] != "138****1234" {
+		t.Fatalf("unexpected detail %v %v", data, err)
+	}
+	// 没有用户信息时不能查看原值,系统调用可以
+	for op, want := range map[*Operator]string{nil: "138****1234", SystemOperator(): "13812341234"} {
+		if data, err = GetDetailById(op, "customer", "1", "", ""); err != nil || data["phone"] != want {
+			t.Fatalf("%+v: unexpected detail %v %v", op, data, err)
+		}
+	}
+
+	resp := &QueryResponse{}
+	Query(&QueryRequest{PageName: "customer", Operator: admin, Data: map[string]interface{}{"phone": "13812341234"}}, resp)
+	if resp.Code != 0 || resp.Total != 1 || resp.Data[0]["phone"] != "13812341234" {
+		t.Fatalf("unexpected query %d %s %v", resp.Code, resp.Message, resp.Data)
+	}
+	resp = &QueryResponse{}
+	Query(&QueryRequest{PageName: "customer", Operator: admin, Data: map[string]interface{}{"phone__like": "138"}}, resp)
+	if resp.Code != 40000 {
+		t.Fatalf("expected bad request, got %d %s", resp.Code, resp.Message)
+	}
+	if err = Create(admin, "customer", map[string]interface{}{"name": "jerry", "phone": "13812341234"}); err == nil {
+		t.Fatal("expected duplication error")
+	}
+
+	// 提交脱敏后的值表示没有修改
+	if err = Update(staff, "customer", map[string]interface{}{"id": 1, "name": "tom2", "phone": "138****1234"}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ = GetDetailById(admin, "customer", "1", "", ""); data["name"] != "tom2" || data["phone"] != "13812341234" {
+		t.Fatalf("unexpected detail %v", data)
+	}
+	if err = Patch(staff, "customer", map[string]interface{}{"id": 1, "phone": "13900001111"}); err != nil {
+		t.Fatal(err)
+	}
+	resp = &QueryResponse{}
+	Query(&QueryRequest{PageName: "customer", Operator: admin, Data: map[string]interface{}{"phone__in": []interface{}{"13900001111", "1"}}}, resp)
+	if resp.Code != 0 || resp.Total != 1 || resp.Data[0]["phone"] != "13900001111" || resp.Data[0]["name"] != "tom2" {
+		t.Fatalf("unexpected query %d %s %v", resp.Code, resp.Message, resp.Data)
+	}
+	// 不能查看原值的用户不能使用脱敏字段查询和排序,避免通过结果推测出原值
+	for _, req := range []*QueryRequest{
+		{PageName: "customer", Operator: staff, Data: map[string]interface{}{"phone": "13900001111"}},
+		{PageName: "customer", Operator: staff, Data: map[string]interface{}{"phone__gte": "139"}},
+		{PageName: "customer", Operator: staff, CommonRequest: pkgmodel.CommonRequest{PageInfo: pkgmodel.PageInfo{OrderField: "phone"}}},
+	} {
+		resp = &QueryResponse{}
+		Query(req, resp)
+		if resp.Code != 40000 {
+			t.Fatalf("%v: expected bad request, got %d %s", req.Data, resp.Code, resp.Message)
+		}
+	}
+	resp = &QueryResponse{}
+	Query(&QueryRequest{PageName: "customer", Operator: staff, Data: map[string]interface{}{"phone__isnull": false}}, resp)
+	if resp.Code != 0 || resp.Total != 1 || resp.Data[0]["phone"] != "139****1111" {
+		t.Fatalf("unexpected query %d %s %v", resp.Code, resp.Message, resp.Data)
+	}
+
+	// 重新加密没有修改的字段不记录变化,变更历史同样解密和脱敏,不返回盲索引
+	history := &HistoryResponse{}
+	History(&HistoryRequest{PageName: "customer", ID: "1", Operator: staff}, history)
+	if history.Code != 0 || len(history.Data) != 3 {
+		t.Fatalf("unexpected history %d %s %v", history.Code, history.Message, history.Data)
+	}
+	if changes := history.Data[1].Changes; len(changes) != 1 || changes[0].Field != "name" {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+	if changes := history.Data[0].Changes; len(changes) != 1 || changes[0].Before != "138****1234" || changes[0].After != "139****1111" {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+	history = &HistoryResponse{}
+	History(&HistoryRequest{PageName: "customer", ID: "1", Operator: admin}, history)
+	if changes := history.Data[0].Changes; history.Code != 0 || len(changes) != 1 || changes[0].After != "13900001111" {
+		t.Fatalf("unexpected history %d %s %v", history.Code, history.Message, history.Data)
+	}
+}
+
+func TestCopySensitiveRecord(t *testing.T) {
+	setEncryptionKey(t)
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "copy.db"), false), true)
+
+	md := &Metadata{Name: "Patient", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"},
+		{Name: "idCard", Type: "varchar", Encrypt: true, Mask: "idCard", UnmaskRoles: "admin"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "patient", Title: "病人", Enable: true, Metadata: md}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE patients(id integer primary key autoincrement, name varchar(20), id_card varchar(200))").Error; err != nil {
+		t.Fatal(err)
+	}
+	staff := &Operator{RoleIDs: []string{"staff"}}
+	if err = Create(staff, "patient", map[string]interface{}{"name": "tom", "idCard": "110101199001011234"}); err != nil {
+		t.Fatal(err)
+	}
+	// 不能查看明文的用户复制时使用原来的明文,而不是脱敏后的值
+	if err = Copy(staff, "patient", "1"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := GetDetailById(&Operator{RoleIDs: []string{"admin"}}, "patient", "2", "", "")
+	if err != nil || data["name"] != "tom Copy" || data["idCard"] != "110101199001011234" {
+		t.Fatalf("unexpected copy %v %v", data, err)
+	}
+}
+
+func TestRestoreSensitiveRecord(t *testing.T) {
+	setEncryptionKey(t)
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "restore.db"), false), true)
+
+	md := &Metadata{Name: "Member", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "phone", Type: "varchar", Unique: true, Encrypt: true, BlindIndexField: "phoneIndex"},
+		{Name: "phoneIndex", Type: "varchar"}, {Name: "deletedAt", Type: "datetime"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "member", Title: "会员", Enable: true, Metadata: md}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE members(id integer primary key autoincrement, phone varchar(200), phone_index varchar(64), deleted_at datetime)").Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = Create(nil, "member", map[string]interface{}{"phone": "13812341234"}); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if err = Delete(nil, "member", "1"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// 加密后的值每次都不同,需要通过盲索引发现重复
+	batch := &BatchResponse{}
+	Restore(&BatchRequest{PageName: "member", IDs: []string{"1"}}, batch)
+	if batch.Code == 0 {
+		t.Fatal("expected duplication error")
+	}
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"github.com/CloudSilk/curd/config"
+	"github.com/CloudSilk/pkg/constants"
+	"gorm.io/gorm"
+)
+
+const TenantColumn = "tenant_id"
+
+// IsTenantMetadata 元数据中定义了TenantID字段,或者开启了租户模式并且表中有tenant_id字段时,数据需要按租户隔离
+func IsTenantMetadata(md *Metadata) bool {
+	if md.FieldByName(TenantColumn) != nil {
+		return true
+	}
+	return config.DefaultConfig.EnableTenant && hasTenantColumn(md)
+}
+
+// tenantColumnCacheTTL 表中是否有tenant_id字段的缓存时间,给表加上字段后最多过这么久才会按租户隔离
+const tenantColumnCacheTTL = time.Minute
+
+type tenantColumn struct {
+	exists  bool
+	expires time.Time
+}
+
+var tenantColumns sync.Map
+
+func hasTenantColumn(md *Metadata) bool {
+	table := NamingStrategy.TableName(md.Name)
+	if v, ok := tenantColumns.Load(table); ok && v.(*tenantColumn).expires.After(time.Now()) {
+		return v.(*tenantColumn).exists
+	}
+	exists := dbClient.DB().Migrator().HasColumn(table, TenantColumn)
+	tenantColumns.Store(table, &tenantColumn{exists: exists, expires: time.Now().Add(tenantColumnCacheTTL)})
+	return exists
+}
+
+// SystemOperator 系统内部调用时使用的平台租户用户,可以访问所有租户的数据,不受数据权限限制
+func SystemOperator() *Operator {
+	return &Operator{TenantID: constants.PlatformTenantID, AllTenants: true, system: true}
+}
+
+// tenantScope 返回需要过滤的租户ID,ok为false表示不需要按租户过滤。
+// 平台租户设置了AllTenants时可以访问所有租户的数据;op为nil时没有租户信息,只能访问租户ID为空的数据,
+// 系统内部调用需要访问所有租户的数据时使用SystemOperator
+func (op *Operator) tenantScope(md *Metadata) (tenantID string, ok bool) {
+	if !IsTenantMetadata(md) {
+		return "", false
+	}
+	if op == nil {
+		return "", true
+	}
+	if op.AllTenants && op.TenantID == constants.PlatformTenantID {
+		return "", false
+	}
+	return op.TenantID, true
+}
+
+// ScopeTenant 给查询加上租户条件
+func ScopeTenant(db *gorm.DB, op *Operator, md *Metadata) *gorm.DB {
+	if tenantID, ok := op.tenantScope(md); ok {
+		return db.Where(QuoteColumn(TenantColumn)+" = ?", tenantID)
+	}
+	return db
+}
+
+// recordDB 返回页面对应的表,并且已经加上了租户和数据权限条件,不包含已经软删除的记录
+func recordDB(tx *gorm.DB, op *Operator, page *Page) *gorm.DB {
+	return ScopeDataRules(metadataDB(tx, op, page.Metadata), op, page)
+}
+
+// uniqueDB 检查唯一字段时需要包含当前用户没有数据权限的记录
+func uniqueDB(tx *gorm.DB, op *Operator, page *Page) *gorm.DB {
+	return metadataDB(tx, op, page.Metadata)
+}
+
+// metadataDB 返回元数据对应的表,并且已经加上了租户条件,不包含已经软删除的记录
+func metadataDB(tx *gorm.DB, op *Operator, md *Metadata) *gorm.DB {
+	db := ScopeTenant(tx.Table(NamingStrategy.TableName(md.Name)), op, md)
+	if IsSoftDeleteMetadata(md) {
+		db = db.Where(QuoteColumn(DeletedAtColumn) + " IS NULL")
+	}
+	return db
+}
+
+// applyTenant 新增记录时使用当前用户的租户ID,平台租户访问所有租户数据时可以指定租户ID
+func applyTenant(op *Operator, md *Metadata, m map[string]interface{}) {
+	if !IsTenantMetadata(md) {
+		return
+	}
+	name := tenantKey(md)
+	tenantID, ok := op.tenantScope(md)
+	if !ok {
+		if m[name] != nil {
+			return
+		}
+		tenantID = op.TenantID
+	}
+	m[name] = tenantID
+}
+
+// tenantKey 返回记录中保存租户ID的key,元数据中定义了TenantID字段时使用字段名
+func tenantKey(md *Metadata) string {
+	if field := md.FieldByName(TenantColumn); field != nil {
+		return field.Name
+	}
+	return TenantColumn
+}
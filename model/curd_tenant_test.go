@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/CloudSilk/curd/config"
+	"github.com/CloudSilk/pkg/constants"
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestTenantScope(t *testing.T) {
+	constants.SetPlatformTenantID("platform")
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "name"}, {Name: "tenantID"}}}
+
+	if tenantID, ok := (*Operator)(nil).tenantScope(md); !ok || tenantID != "" {
+		t.Fatal("nil operator should be scoped to empty tenant")
+	}
+	if _, ok := SystemOperator().tenantScope(md); ok {
+		t.Fatal("system operator should access all tenants")
+	}
+	if tenantID, ok := (&Operator{TenantID: "a", AllTenants: true}).tenantScope(md); !ok || tenantID != "a" {
+		t.Fatal("only platform tenant can access all tenants")
+	}
+	if _, ok := (&Operator{TenantID: "platform", AllTenants: true}).tenantScope(md); ok {
+		t.Fatal("platform tenant should be able to opt out")
+	}
+	if tenantID, ok := (&Operator{TenantID: "platform"}).tenantScope(md); !ok || tenantID != "platform" {
+		t.Fatal("platform tenant should be scoped by default")
+	}
+	if _, ok := (&Operator{TenantID: "a"}).tenantScope(&Metadata{}); ok {
+		t.Fatal("metadata without tenant field should not be scoped")
+	}
+
+	m := map[string]interface{}{"tenantID": "b"}
+	applyTenant(&Operator{TenantID: "a"}, md, m)
+	if m["tenantID"] != "a" {
+		t.Fatalf("expected tenant a, got %v", m["tenantID"])
+	}
+	m = map[string]interface{}{"tenantID": "b"}
+	applyTenant(&Operator{TenantID: "platform", AllTenants: true}, md, m)
+	if m["tenantID"] != "b" {
+		t.Fatalf("expected tenant b, got %v", m["tenantID"])
+	}
+}
+
+func TestTenantRecords(t *testing.T) {
+	old, enable := dbClient, config.DefaultConfig.EnableTenant
+	defer func() { dbClient, config.DefaultConfig.EnableTenant = old, enable }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "tenant.db"), false), true)
+	config.DefaultConfig.EnableTenant = true
+
+	for _, page := range []*Page{
+		{Name: "tenant_order", Title: "订单", Enable: true, Metadata: &Metadata{Name: "TenantOrder", MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}}}},
+		{Name: "shared_unit", Title: "单位", Enable: true, Metadata: &Metadata{Name: "SharedUnit", MetadataFields: []*MetadataField{{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}}}},
+	} {
+		if err := dbClient.DB().Create(page).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := dbClient.DB().Exec("CREATE TABLE tenant_orders(id integer primary key autoincrement, name varchar(20), tenant_id varchar(36))").Error
+	if err == nil {
+		err = dbClient.DB().Exec("CREATE TABLE shared_units(id integer primary key autoincrement, name varchar(20))").Error
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := &Operator{TenantID: "a"}, &Operator{TenantID: "b"}
+	// 表中没有tenant_id字段时不按租户隔离
+	if err = Create(a, "shared_unit", map[string]interface{}{"name": "kg"}); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := GetAll(b, "shared_unit"); err != nil || len(list) != 1 {
+		t.Fatalf("unexpected units %v %v", list, err)
+	}
+
+	if err = Create(a, "tenant_order", map[string]interface{}{"name": "apple"}); err != nil {
+		t.Fatal(err)
+	}
+	for op, count := range map[*Operator]int{a: 1, b: 0, nil: 0, SystemOperator(): 1} {
+		if list, err := GetAll(op, "tenant_order"); err != nil || len(list) != count {
+			t.Fatalf("%+v: unexpected orders %v %v", op, list, err)
+		}
+	}
+	if err = Copy(b, "tenant_order", "1"); !errors.Is(err, ErrRecordNotExist) {
+		t.Fatalf("expected ErrRecordNotExist, got %v", err)
+	}
+	if list, _ := GetAll(SystemOperator(), "tenant_order"); len(list) != 1 {
+		t.Fatalf("copy should not create records: %v", list)
+	}
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const DeletedAtColumn = "deleted_at"
+
+var ErrSoftDeleteNotSupported = errors.New("元数据没有DeletedAt字段,不支持回收站")
+
+// IsSoftDeleteMetadata 元数据中定义了DeletedAt字段时,删除只设置删除时间
+func IsSoftDeleteMetadata(md *Metadata) bool {
+	return md.FieldByName(DeletedAtColumn) != nil
+}
+
+// tableDB 返回页面对应的表,包含已经删除的记录
+func tableDB(tx *gorm.DB, op *Operator, page *Page) *gorm.DB {
+	return ScopeTenant(tx.Table(NamingStrategy.TableName(page.Metadata.Name)), op, page.Metadata)
+}
+
+// trashDB 返回回收站中当前用户有数据权限的记录
+func trashDB(tx *gorm.DB, op *Operator, page *Page) *gorm.DB {
+	return ScopeDataRules(tableDB(tx, op, page).Where(QuoteColumn(DeletedAtColumn)+" IS NOT NULL"), op, page)
+}
+
+// Trash 分页查询回收站中的记录
+func Trash(req *QueryRequest, resp *QueryResponse) {
+	query(req, resp, true)
+}
+
+func checkSoftDelete(page *Page) error {
+	if !IsSoftDeleteMetadata(page.Metadata) {
+		return ErrSoftDeleteNotSupported
+	}
+	return nil
+}
+
+// Restore 恢复回收站中的记录
+func Restore(req *BatchRequest, resp *BatchResponse) {
+	runBatch(req, resp, checkSoftDelete, func(tx *gorm.DB, page *Page, id string) error {
+		return restoreByID(tx, req.Operator, page, id)
+	})
+}
+
+// Purge 彻底删除回收站中的记录
+func Purge(req *BatchRequest, resp *BatchResponse) {
+	runBatch(req, resp, checkSoftDelete, func(tx *gorm.DB, page *Page, id string) error {
+		before, err := auditSnapshot(tx, page, id)
+		if err != nil {
+			return err
+		}
+		if err = hardDeleteByID(trashDB(tx, req.Operator, page), id); err != nil {
+			return err
+		}
+		if err = deleteChildren(tx, req.Operator, page.Metadata, id); err != nil {
+			return err
+		}
+		return writeAudit(tx, req.Operator, page, AuditActionPurge, id, before)
+	})
+}
+
+func softDeleteByID(tx *gorm.DB, op *Operator, page *Page, id string) error {
+	result := recordDB(tx, op, page).Where("id = ?", id).Update(DeletedAtColumn, time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotExist
+	}
+	return nil
+}
+
+// restoreByID 恢复记录之前需要检查唯一字段是否和未删除的记录重复
+func restoreByID(tx *gorm.DB, op *Operator, page *Page, id string) error {
+	md := page.Metadata
+	if !IsSoftDeleteMetadata(md) {
+		return ErrSoftDeleteNotSupported
+	}
+	var rows []map[string]interface{}
+	err := trashDB(tx, op, page).Where("id = ?", id).Limit(1).Find(&rows).Error
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return ErrRecordNotExist
+	}
+	uniqueFields := []string{"id <> ?"}
+	fieldValues := []interface{}{id}
+	for _, field := range md.MetadataFields {
+		if field.Unique {
+			// 加密字段每次加密的结果不同,使用盲索引比较
+			unique, err := uniqueField(md, field)
+			if err != nil {
+				return err
+			}
+			column := LowerSnakeCase(unique.Name)
+			uniqueFields = append(uniqueFields, QuoteColumn(column)+" = ?")
+			fieldValues = append(fieldValues, rows[0][column])
+		}
+	}
+	if len(uniqueFields) > 1 {
+		duplication, err := dbClient.CheckDuplication(uniqueDB(tx, op, page), strings.Join(uniqueFields, " and "), fieldValues...)
+		if err != nil {
+			return err
+		}
+		if duplication {
+			return errors.New("存在相同" + page.Title + ",不能恢复")
+		}
+	}
+	err = tableDB(tx, op, page).Where("id = ?", id).Update(DeletedAtColumn, nil).Error
+	if err != nil {
+		return err
+	}
+	return writeAudit(tx, op, page, AuditActionRestore, id, rows[0])
+}
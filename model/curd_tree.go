@@ -0,0 +1,338 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ParentIDColumn = "parent_id"
+	LevelColumn    = "level"
+)
+
+// maxTreeDepth 防止数据中已经存在环时无限循环
+const maxTreeDepth = 100
+
+var (
+	ErrNotTreeMetadata = errors.New("不是树形接口的数据,不存在parentID")
+	ErrTreeCycle       = errors.New("不能移动到自己或者自己的子节点下面")
+)
+
+func GetTree(op *Operator, pageName string) (list []map[string]interface{}, total int64, err error) {
+	page, err := GetPageByName(pageName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	treeMap, total, err := getTreeMap(op, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	menuList := treeMap[""]
+	for i := 0; i < len(menuList); i++ {
+		if err = GetChildrenList(menuList[i], treeMap); err != nil {
+			return nil, 0, err
+		}
+	}
+	return menuList, total, nil
+}
+
+func GetTreeMap(op *Operator, page *Page) (treeMap map[string][]map[string]interface{}, err error) {
+	treeMap, _, err = getTreeMap(op, page)
+	return
+}
+
+// getTreeMap 按照parentID分组,根节点的parentID为空或者0,key是字符串形式的parentID
+func getTreeMap(op *Operator, page *Page) (map[string][]map[string]interface{}, int64, error) {
+	if page.Metadata.FieldByName(ParentIDColumn) == nil {
+		return nil, 0, ErrNotTreeMetadata
+	}
+	var all []map[string]interface{}
+	treeMap := make(map[string][]map[string]interface{})
+	err := recordDB(dbClient.DB(), op, page).Order(treeOrder(page.Metadata)).Find(&all).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	permissions := fieldPermissions(op, page)
+	for _, v := range all {
+		d, err := treeNode(op, page, permissions, v)
+		if err != nil {
+			return nil, 0, err
+		}
+		parentID := treeKey(v[ParentIDColumn])
+		treeMap[parentID] = append(treeMap[parentID], d)
+	}
+	return treeMap, int64(len(all)), nil
+}
+
+func GetChildrenList(location map[string]interface{}, treeMap map[string][]map[string]interface{}) (err error) {
+	id, ok := location["id"]
+	if !ok {
+		return fmt.Errorf("不是树形接口的数据,不存在ID")
+	}
+	children, ok := treeMap[treeKey(id)]
+	if !ok {
+		return nil
+	}
+	location["children"] = children
+	for i := 0; i < len(children); i++ {
+		err = GetChildrenList(children[i], treeMap)
+	}
+	return err
+}
+
+// TreeChildren 查询parentID的直接子节点,用于树的懒加载,parentID为空时查询根节点,
+// 每个节点的isLeaf表示是否没有子节点
+func TreeChildren(op *Operator, pageName, parentID string) ([]map[string]interface{}, error) {
+	page, err := GetPageByName(pageName)
+	if err != nil {
+		return nil, err
+	}
+	md := page.Metadata
+	parentField := md.FieldByName(ParentIDColumn)
+	if parentField == nil {
+		return nil, ErrNotTreeMetadata
+	}
+	db := recordDB(dbClient.DB(), op, page)
+	if treeKey(parentID) == "" {
+		db = whereTreeRoot(db, parentField)
+	} else {
+		db = db.Where(QuoteColumn(ParentIDColumn)+" = ?", parentID)
+	}
+	var rows []map[string]interface{}
+	if err = db.Order(treeOrder(md)).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	ids := make([]interface{}, len(rows))
+	for i, row := range rows {
+		ids[i] = derefValue(row["id"])
+	}
+	var parentIDs []interface{}
+	err = recordDB(dbClient.DB(), op, page).Where(QuoteColumn(ParentIDColumn)+" IN ?", ids).Distinct(ParentIDColumn).Pluck(ParentIDColumn, &parentIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	hasChildren := make(map[string]bool)
+	for _, id := range parentIDs {
+		hasChildren[treeKey(id)] = true
+	}
+
+	permissions := fieldPermissions(op, page)
+	list := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		d, err := treeNode(op, page, permissions, row)
+		if err != nil {
+			return nil, err
+		}
+		d["isLeaf"] = !hasChildren[treeKey(row["id"])]
+		list[i] = d
+	}
+	return list, nil
+}
+
+// TreePath 返回从根节点到id的所有节点,用于面包屑导航
+func TreePath(op *Operator, pageName, id string) ([]map[string]interface{}, error) {
+	page, err := GetPageByName(pageName)
+	if err != nil {
+		return nil, err
+	}
+	if page.Metadata.FieldByName(ParentIDColumn) == nil {
+		return nil, ErrNotTreeMetadata
+	}
+	permissions := fieldPermissions(op, page)
+	var path []map[string]interface{}
+	var current interface{} = id
+	for depth := 0; treeKey(current) != ""; depth++ {
+		if depth >= maxTreeDepth {
+			return nil, fmt.Errorf("树的层级超过%d,可能存在循环引用", maxTreeDepth)
+		}
+		var rows []map[string]interface{}
+		err = recordDB(dbClient.DB(), op, page).Where("id = ?", current).Limit(1).Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			if depth == 0 {
+				return nil, ErrRecordNotExist
+			}
+			break
+		}
+		node, err := treeNode(op, page, permissions, rows[0])
+		if err != nil {
+			return nil, err
+		}
+		path = append([]map[string]interface{}{node}, path...)
+		current = derefValue(rows[0][ParentIDColumn])
+	}
+	return path, nil
+}
+
+type TreeMoveRequest struct {
+	PageName string `json:"pageName"`
+	ID       string `json:"id" validate:"required"`
+	// ParentID 新的父节点,为空时移动到根节点
+	ParentID string    `json:"parentID"`
+	Operator *Operator `json:"-"`
+}
+
+// MoveTreeNode 修改节点的父节点,同时重新计算节点和所有子节点的level,
+// 不能移动到自己或者自己的子节点下面
+func MoveTreeNode(req *TreeMoveRequest) error {
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		return err
+	}
+	md := page.Metadata
+	parentField := md.FieldByName(ParentIDColumn)
+	if parentField == nil {
+		return ErrNotTreeMetadata
+	}
+	op := req.Operator
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		before, err := lockRecord(tx, op, page, req.ID)
+		if err != nil {
+			return err
+		}
+		id := treeKey(before["id"])
+
+		var parentID interface{}
+		level := int64(0)
+		if treeKey(req.ParentID) != "" {
+			parent, err := lockRecord(tx, op, page, req.ParentID)
+			if err != nil {
+				return fmt.Errorf("父节点%s:%w", req.ParentID, err)
+			}
+			if err = checkTreeCycle(tx, op, page, id, parent); err != nil {
+				return err
+			}
+			if parentID, err = ConvertFieldValue(parentField, derefValue(parent["id"])); err != nil {
+				return err
+			}
+			// 父节点的level为空时按根节点处理
+			level = 1
+			if parentLevel, err := toInt64(derefValue(parent[LevelColumn])); err == nil {
+				level = parentLevel.(int64) + 1
+			}
+		} else if isNumberType(parentField.Type) {
+			parentID = 0
+		} else {
+			parentID = ""
+		}
+
+		// 父节点按照普通的更新处理,同样需要检查字段权限和数据权限,执行钩子并写入审计和推送,
+		// level由系统维护,和父节点一起更新,这样审计和推送里能看到level的变化
+		var system map[string]interface{}
+		levelField := md.FieldByName(LevelColumn)
+		if levelField != nil {
+			system = map[string]interface{}{levelField.Name: level}
+		}
+		err = updateSystemFieldsByID(tx, op, page, id, map[string]interface{}{parentField.Name: parentID}, system)
+		if err != nil || levelField == nil {
+			return err
+		}
+		return updateSubtreeLevel(tx, op, page, id, level)
+	})
+}
+
+// checkTreeCycle 从新的父节点向上查找,如果经过要移动的节点说明会形成环,
+// 需要包含当前用户没有数据权限的节点
+func checkTreeCycle(tx *gorm.DB, op *Operator, page *Page, id string, parent map[string]interface{}) error {
+	current := parent
+	for depth := 0; ; depth++ {
+		if depth >= maxTreeDepth {
+			return fmt.Errorf("树的层级超过%d,可能存在循环引用", maxTreeDepth)
+		}
+		if treeKey(current["id"]) == id {
+			return ErrTreeCycle
+		}
+		parentID := derefValue(current[ParentIDColumn])
+		if treeKey(parentID) == "" {
+			return nil
+		}
+		var rows []map[string]interface{}
+		if err := metadataDB(tx, op, page.Metadata).Where("id = ?", parentID).Limit(1).Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		current = rows[0]
+	}
+}
+
+// updateSubtreeLevel 逐层更新子节点的level,包括当前用户没有数据权限的子节点
+func updateSubtreeLevel(tx *gorm.DB, op *Operator, page *Page, id string, level int64) error {
+	parents := []interface{}{id}
+	for depth := 1; len(parents) > 0; depth++ {
+		if depth > maxTreeDepth {
+			return fmt.Errorf("树的层级超过%d,可能存在循环引用", maxTreeDepth)
+		}
+		var children []interface{}
+		err := metadataDB(tx, op, page.Metadata).Where(QuoteColumn(ParentIDColumn)+" IN ?", parents).Pluck("id", &children).Error
+		if err != nil {
+			return err
+		}
+		if len(children) == 0 {
+			return nil
+		}
+		err = metadataDB(tx, op, page.Metadata).Where("id IN ?", children).Update(LevelColumn, level+int64(depth)).Error
+		if err != nil {
+			return err
+		}
+		parents = children
+	}
+	return nil
+}
+
+// whereTreeRoot 根节点的parentID为NULL、空字符串或者0
+func whereTreeRoot(db *gorm.DB, parentField *MetadataField) *gorm.DB {
+	column := QuoteColumn(ParentIDColumn)
+	if isNumberType(parentField.Type) {
+		return db.Where("(" + column + " IS NULL OR " + column + " = 0)")
+	}
+	return db.Where("(" + column + " IS NULL OR " + column + " = '' OR " + column + " = '0')")
+}
+
+func treeOrder(md *Metadata) string {
+	if md.FieldByName(LevelColumn) != nil {
+		return QuoteColumn(LevelColumn) + "," + QuoteColumn("id")
+	}
+	return QuoteColumn("id")
+}
+
+// treeNode 转换成前端树组件需要的格式,key是id,title是name
+func treeNode(op *Operator, page *Page, permissions map[string]string, row map[string]interface{}) (map[string]interface{}, error) {
+	d := make(map[string]interface{})
+	for key, value := range row {
+		d[CamelName2(key)] = value
+	}
+	stripHiddenFields(permissions, d)
+	if err := revealRecords(op, page.Metadata, d); err != nil {
+		return nil, err
+	}
+	d["key"] = d["id"]
+	d["title"] = d["name"]
+	return d, nil
+}
+
+// treeKey 把id或者parentID转换成字符串,NULL、空字符串和0都表示根节点
+func treeKey(value interface{}) string {
+	value = derefValue(value)
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	if value == nil {
+		return ""
+	}
+	key := fmt.Sprint(value)
+	if key == "0" {
+		return ""
+	}
+	return key
+}
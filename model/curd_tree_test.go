@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestTreeKey(t *testing.T) {
+	var nilID *int64
+	id := int64(5)
+	for _, c := range []struct {
+		value interface{}
+		want  string
+	}{{nil, ""}, {nilID, ""}, {"", ""}, {int64(0), ""}, {"0", ""}, {&id, "5"}, {[]byte("ab"), "ab"}, {"uuid", "uuid"}} {
+		if got := treeKey(c.value); got != c.want {
+			t.Errorf("treeKey(%v): expected %q, got %q", c.value, c.want, got)
+		}
+	}
+}
+
+func TestGetChildrenList(t *testing.T) {
+	root := map[string]interface{}{"id": int64(1)}
+	child := map[string]interface{}{"id": int64(2)}
+	treeMap := map[string][]map[string]interface{}{"1": {child}, "2": {{"id": int64(3)}}}
+	if err := GetChildrenList(root, treeMap); err != nil {
+		t.Fatal(err)
+	}
+	children, _ := root["children"].([]map[string]interface{})
+	if len(children) != 1 || child["children"] == nil {
+		t.Fatalf("unexpected tree %v", root)
+	}
+}
+
+func TestMoveTreeNode(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "tree.db"), false), true)
+
+	md := &Metadata{Name: "TreeDept", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}, {Name: "parentID", Type: "bigint"}, {Name: "level", Type: "int"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "tree_dept", Title: "部门", Enable: true, EnableAudit: true, Metadata: md,
+		FieldPermissions: []*PageFieldPermission{{RoleID: "viewer", Field: "parentID", Permission: FieldPermissionReadOnly}}}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dbClient.DB().Exec("CREATE TABLE tree_depts(id integer primary key autoincrement, name varchar(20), parent_id bigint, level int)").Error; err != nil {
+		t.Fatal(err)
+	}
+	dbClient.DB().Exec("INSERT INTO tree_depts(name, parent_id, level) VALUES ('a', 0, 0), ('b', 0, 0), ('c', 2, 1)")
+	// 移动节点和普通修改一样执行钩子,钩子加入的非元数据字段不保存
+	var moved []interface{}
+	RegisterHook("tree_dept", HookBeforeUpdate, func(ctx *HookContext) error {
+		moved = append(moved, ctx.Data["parentID"])
+		ctx.Data["remark"] = "moved"
+		return nil
+	})
+
+	err = MoveTreeNode(&TreeMoveRequest{PageName: "tree_dept", ID: "2", ParentID: "1", Operator: &Operator{RoleIDs: []string{"viewer"}}})
+	if _, ok := err.(ValidationErrors); !ok {
+		t.Fatalf("expected read only error, got %v", err)
+	}
+	if err = MoveTreeNode(&TreeMoveRequest{PageName: "tree_dept", ID: "2", ParentID: "1", Operator: SystemOperator()}); err != nil {
+		t.Fatal(err)
+	}
+	if len(moved) != 1 || fmt.Sprint(moved[0]) != "1" {
+		t.Fatalf("unexpected hook calls %v", moved)
+	}
+	var levels []int64
+	dbClient.DB().Table("tree_depts").Order("id").Pluck("level", &levels)
+	if fmt.Sprint(levels) != "[0 1 2]" {
+		t.Fatalf("unexpected levels %v", levels)
+	}
+	var logs []*AuditLog
+	dbClient.DB().Where("page_name = ? AND record_id = ?", "tree_dept", "2").Find(&logs)
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(logs))
+	}
+	// 审计里要有level的变化
+	changes := make(map[string]string)
+	for _, change := range logs[0].Changes {
+		changes[change.Field] = fmt.Sprint(change.Before, "->", change.After)
+	}
+	if changes["level"] != "0->1" || changes["parentid"] != "0->1" {
+		t.Fatalf("unexpected audit changes %v", changes)
+	}
+}
@@ -0,0 +1,268 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	apipb "github.com/CloudSilk/curd/proto"
+	"github.com/CloudSilk/pkg/model"
+	"gorm.io/gorm"
+)
+
+// ValidationErrors 字段校验失败的信息,key是字段名,value是错误信息
+type ValidationErrors map[string]string
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, message := range e {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	return strings.Join(messages, ";")
+}
+
+// 由数据库或者系统自动维护的字段,不需要校验是否为空
+var autoFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+}
+
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+// ValidateRecord 根据元数据校验记录,校验通过后会把m中的值转换成字段对应的类型
+// partial为true时只校验m中存在的字段,引用的记录必须是当前租户中没有删除的记录
+func ValidateRecord(tx *gorm.DB, op *Operator, md *Metadata, m map[string]interface{}, partial bool) error {
+	errs := ValidationErrors{}
+	for _, field := range md.MetadataFields {
+		if IsRelationField(md, field) {
+			continue
+		}
+		value, ok := m[field.Name]
+		if partial && !ok {
+			continue
+		}
+		value = derefValue(value)
+		if isEmptyValue(value) {
+			if field.NotNull && !autoFields[LowerSnakeCase(field.Name)] {
+				errs[field.Name] = fieldTitle(field) + "不能为空"
+			}
+			continue
+		}
+		value, err := ConvertFieldValue(field, value)
+		if err != nil {
+			errs[field.Name] = fieldTitle(field) + err.Error()
+			continue
+		}
+		m[field.Name] = value
+		if field.RefMetadata != "" {
+			exists, err := refRecordExists(tx, op, field.RefMetadata, value)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				errs[field.Name] = fmt.Sprintf("%s引用的记录%v不存在", fieldTitle(field), value)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// derefValue 从数据库读取到map中的值可能是指针
+func derefValue(value interface{}) interface{} {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		return rv.Elem().Interface()
+	}
+	return value
+}
+
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	str, ok := value.(string)
+	return ok && strings.TrimSpace(str) == ""
+}
+
+// ConvertFieldValue 把请求中的值转换成字段类型对应的值,同时校验字符串长度
+func ConvertFieldValue(field *MetadataField, value interface{}) (interface{}, error) {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	switch field.Type {
+	case "varchar", "nvarchar", "string", "longtext", "nvarchar(max)", "text":
+		str, ok := value.(string)
+		if !ok {
+			str = fmt.Sprint(value)
+		}
+		if field.Encrypt {
+			// 加密字段的长度是密文的长度
+			if field.Length > 0 && EncryptedLength(len(str)) > int(field.Length) {
+				return nil, fmt.Errorf("加密后的长度不能超过%d", field.Length)
+			}
+			return str, nil
+		}
+		if field.Length > 0 && utf8.RuneCountInString(str) > int(field.Length) {
+			return nil, fmt.Errorf("长度不能超过%d", field.Length)
+		}
+		return str, nil
+	case "int", "int32", "int64", "bigint", "uint", "smallint":
+		return toInt64(value)
+	case "float", "float32", "float64", "double", "decimal":
+		return toFloat64(value)
+	case "bool", "tinyint", "bit":
+		return toBool(value)
+	case "datetime", "date", "timestamp":
+		return toTime(value)
+	default:
+		return value, nil
+	}
+}
+
+func toInt64(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, fmt.Errorf("必须是整数")
+		}
+		return int64(v), nil
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("必须是整数")
+		}
+		return i, nil
+	}
+	return nil, fmt.Errorf("必须是整数")
+}
+
+func toFloat64(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("必须是数字")
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("必须是数字")
+}
+
+func toBool(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	case int64:
+		return v != 0, nil
+	case int:
+		return v != 0, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("必须是true或者false")
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("必须是true或者false")
+}
+
+func toTime(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		for _, layout := range dateTimeLayouts {
+			if t, err := time.ParseInLocation(layout, strings.TrimSpace(v), time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("不是有效的时间")
+}
+
+// refMetadataCacheTTL 引用的元数据的缓存时间,导入时不需要每一行都查询元数据。
+// 修改和删除元数据时会清除本地缓存,其他实例最多过这么久才会生效
+const refMetadataCacheTTL = time.Minute
+
+type cachedMetadata struct {
+	md      *Metadata
+	expires time.Time
+}
+
+var refMetadataCache sync.Map
+
+func refMetadata(id string) (*Metadata, error) {
+	if v, ok := refMetadataCache.Load(id); ok && v.(*cachedMetadata).expires.After(time.Now()) {
+		return v.(*cachedMetadata).md, nil
+	}
+	md, err := GetMetadataById(id)
+	if err != nil {
+		return nil, err
+	}
+	refMetadataCache.Store(id, &cachedMetadata{md: md, expires: time.Now().Add(refMetadataCacheTTL)})
+	return md, nil
+}
+
+func refRecordExists(tx *gorm.DB, op *Operator, refMetadataID string, id interface{}) (bool, error) {
+	md, err := refMetadata(refMetadataID)
+	if err != nil {
+		return false, err
+	}
+	var count int64
+	err = metadataDB(tx, op, md).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// SaveResponse 新增或者更新的返回结果,校验失败时Errors包含每个字段的错误信息
+type SaveResponse struct {
+	model.CommonResponse
+	Errors ValidationErrors `json:"errors"`
+}
+
+// SetError 根据错误类型设置返回码,字段校验失败返回BadRequest
+func (resp *SaveResponse) SetError(err error) {
+	resp.Message = err.Error()
+	if errs, ok := err.(ValidationErrors); ok {
+		resp.Code = model.BadRequest
+		resp.Errors = errs
+		return
+	}
+	resp.Code = int(ErrorCode(err, apipb.Code_InternalServerError))
+}
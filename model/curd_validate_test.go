@@ -0,0 +1,71 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+func TestValidateRecord(t *testing.T) {
+	md := &Metadata{
+		MetadataFields: []*MetadataField{
+			{Name: "id", Type: "varchar", NotNull: true},
+			{Name: "name", DisplayName: "名称", Type: "varchar", Length: 4, NotNull: true},
+			{Name: "age", Type: "int"},
+			{Name: "price", Type: "decimal"},
+			{Name: "enable", Type: "bool"},
+			{Name: "birthday", Type: "datetime"},
+		},
+	}
+
+	m := map[string]interface{}{"name": "张三", "age": "18", "price": 1.5, "enable": "true", "birthday": "2023-01-02"}
+	if err := ValidateRecord(nil, nil, md, m, false); err != nil {
+		t.Fatal(err)
+	}
+	if m["age"] != int64(18) || m["enable"] != true {
+		t.Fatalf("unexpected converted values %v", m)
+	}
+
+	err := ValidateRecord(nil, nil, md, map[string]interface{}{"name": "12345", "age": 1.5, "enable": "x", "birthday": "abc"}, false)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v", err)
+	}
+
+	err = ValidateRecord(nil, nil, md, map[string]interface{}{"age": 1}, false)
+	if errs, ok = err.(ValidationErrors); !ok || errs["name"] != "名称不能为空" {
+		t.Fatalf("expected name required, got %v", err)
+	}
+
+	if err = ValidateRecord(nil, nil, md, map[string]interface{}{"age": 1}, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRefRecordExists(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "ref.db"), false), true)
+
+	md := &Metadata{Name: "RefCustomer", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "tenantID", Type: "varchar"}, {Name: "deletedAt", Type: "datetime"},
+	}}
+	if err := dbClient.DB().Create(md).Error; err != nil {
+		t.Fatal(err)
+	}
+	err := dbClient.DB().Exec("CREATE TABLE ref_customers(id integer primary key, tenant_id varchar(36), deleted_at datetime)").Error
+	if err == nil {
+		err = dbClient.DB().Exec("INSERT INTO ref_customers VALUES (1, 'a', NULL), (2, 'b', NULL), (3, 'a', '2023-01-01')").Error
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 只能引用当前租户中没有删除的记录
+	op := &Operator{TenantID: "a"}
+	for id, want := range map[int]bool{1: true, 2: false, 3: false, 4: false} {
+		if exists, err := refRecordExists(dbClient.DB(), op, md.ID, id); err != nil || exists != want {
+			t.Errorf("%d: expected %v, got %v %v", id, want, exists, err)
+		}
+	}
+}
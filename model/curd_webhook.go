@@ -0,0 +1,517 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CloudSilk/curd/config"
+	"github.com/CloudSilk/pkg/model"
+	"github.com/CloudSilk/pkg/utils/log"
+	"gorm.io/gorm"
+)
+
+const (
+	WebhookStatusPending = "pending"
+	WebhookStatusSuccess = "success"
+	WebhookStatusFailed  = "failed"
+)
+
+// 推送webhook时的请求头,签名是HMAC-SHA256(secret, timestamp + "." + body)
+const (
+	WebhookHeaderEvent     = "X-Curd-Event"
+	WebhookHeaderPage      = "X-Curd-Page"
+	WebhookHeaderDelivery  = "X-Curd-Delivery"
+	WebhookHeaderTimestamp = "X-Curd-Timestamp"
+	WebhookHeaderSignature = "X-Curd-Signature"
+)
+
+// WebhookSecretMask 读取页面配置时代替已经设置的secret,保存时传回这个值表示不修改secret
+const WebhookSecretMask = "******"
+
+// webhookActions webhook可以订阅的事件
+var webhookActions = map[string]bool{
+	AuditActionCreate: true,
+	AuditActionUpdate: true,
+	AuditActionDelete: true,
+}
+
+// OutboxEvent 记录的变更事件,和记录在同一个事务中写入,由WebhookDispatcher推送到页面配置的webhook
+type OutboxEvent struct {
+	model.TenantModel
+	PageName     string                 `json:"pageName" gorm:"size:100"`
+	RecordID     string                 `json:"recordID" gorm:"size:36"`
+	Action       string                 `json:"action" gorm:"size:20;comment:create,update,delete"`
+	UserID       string                 `json:"userID" gorm:"size:36"`
+	TransID      string                 `json:"transID" gorm:"size:64"`
+	Data         map[string]interface{} `json:"data" gorm:"type:text;serializer:json;comment:新增和修改后的记录,删除前的记录"`
+	DispatchedAt *time.Time             `json:"dispatchedAt" gorm:"index;comment:生成推送记录的时间"`
+}
+
+// WebhookDelivery 事件推送到一个webhook的记录,同时也是推送日志
+type WebhookDelivery struct {
+	model.TenantModel
+	EventID      string     `json:"eventID" gorm:"size:36;index"`
+	PageName     string     `json:"pageName" gorm:"size:100;index:webhook_delivery_idx1"`
+	RecordID     string     `json:"recordID" gorm:"size:36;index:webhook_delivery_idx1"`
+	Action       string     `json:"action" gorm:"size:20"`
+	WebhookID    string     `json:"webhookID" gorm:"size:36"`
+	URL          string     `json:"url" gorm:"size:500"`
+	Status       string     `json:"status" gorm:"size:20;index:webhook_delivery_idx2;comment:pending,success,failed"`
+	Attempts     int        `json:"attempts" gorm:"comment:已经推送的次数"`
+	NextRetryAt  time.Time  `json:"nextRetryAt" gorm:"index:webhook_delivery_idx2"`
+	ResponseCode int        `json:"responseCode"`
+	LastError    string     `json:"lastError" gorm:"size:500"`
+	DeliveredAt  *time.Time `json:"deliveredAt"`
+}
+
+// WebhookPayload 推送给webhook的内容
+type WebhookPayload struct {
+	ID        string                 `json:"id"`
+	PageName  string                 `json:"pageName"`
+	Action    string                 `json:"action"`
+	RecordID  string                 `json:"recordID"`
+	TenantID  string                 `json:"tenantID"`
+	UserID    string                 `json:"userID"`
+	TransID   string                 `json:"transID"`
+	Data      map[string]interface{} `json:"data"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// CheckPageWebhooks 保存页面配置时检查webhook的地址和事件
+func CheckPageWebhooks(webhooks []*PageWebhook) error {
+	for _, webhook := range webhooks {
+		u, err := url.Parse(webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook地址%s无效", webhook.URL)
+		}
+		for _, action := range strings.Split(webhook.Events, ",") {
+			if action = strings.TrimSpace(action); action != "" && !webhookActions[action] {
+				return fmt.Errorf("webhook不支持的事件:%s", action)
+			}
+		}
+	}
+	return nil
+}
+
+// keepWebhookSecrets 保存页面配置时把传回的WebhookSecretMask换成原来的secret,
+// 原来没有这个webhook时清空,old为nil表示新增页面
+func keepWebhookSecrets(old, m *Page) {
+	secrets := make(map[string]string)
+	if old != nil {
+		for _, webhook := range old.Webhooks {
+			secrets[webhook.ID] = webhook.Secret
+		}
+	}
+	for _, webhook := range m.Webhooks {
+		if webhook.Secret == WebhookSecretMask {
+			webhook.Secret = secrets[webhook.ID]
+		}
+	}
+}
+
+// webhookAccepts webhook是否订阅了action,Events为空时订阅所有事件
+func webhookAccepts(webhook *PageWebhook, action string) bool {
+	if !webhook.Enable {
+		return false
+	}
+	if strings.TrimSpace(webhook.Events) == "" {
+		return true
+	}
+	for _, event := range strings.Split(webhook.Events, ",") {
+		if strings.TrimSpace(event) == action {
+			return true
+		}
+	}
+	return false
+}
+
+func hasWebhooks(page *Page, action string) bool {
+	for _, webhook := range page.Webhooks {
+		if webhookAccepts(webhook, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// outboxSnapshot 删除前读取记录,页面没有订阅删除事件时返回nil
+func outboxSnapshot(tx *gorm.DB, page *Page, id interface{}) (map[string]interface{}, error) {
+	if !hasWebhooks(page, AuditActionDelete) || id == nil {
+		return nil, nil
+	}
+	return loadRecord(tx, page, id)
+}
+
+// writeOutbox 页面有订阅action的webhook时写入变更事件,row为nil时读取记录当前的数据
+func writeOutbox(tx *gorm.DB, op *Operator, page *Page, action string, id interface{}, row map[string]interface{}) error {
+	if !hasWebhooks(page, action) || id == nil {
+		return nil
+	}
+	if row == nil {
+		var err error
+		if row, err = loadRecord(tx, page, id); err != nil {
+			return err
+		}
+	}
+	data, err := outboxData(page, row)
+	if err != nil {
+		return err
+	}
+	event := &OutboxEvent{
+		PageName: page.Name,
+		RecordID: fmt.Sprint(id),
+		Action:   action,
+		Data:     data,
+	}
+	if op != nil {
+		event.TenantID = op.TenantID
+		event.UserID = op.UserID
+		event.TransID = op.TransID
+	}
+	if tenantID, ok := row[TenantColumn]; ok && tenantID != nil {
+		event.TenantID = fmt.Sprint(auditValue(tenantID))
+	}
+	return tx.Create(event).Error
+}
+
+// outboxData 推送到外部系统的记录不包含加密字段和盲索引,脱敏字段推送脱敏后的值;
+// 接收方不属于任何角色,按照默认的字段权限删除隐藏字段
+func outboxData(page *Page, row map[string]interface{}) (map[string]interface{}, error) {
+	md := page.Metadata
+	data := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		data[CamelName2(column)] = auditValue(value)
+	}
+	for _, field := range md.MetadataFields {
+		if field.Encrypt {
+			delete(data, CamelName2(LowerSnakeCase(field.Name)))
+		}
+	}
+	receiver := &Operator{}
+	if err := revealRecords(receiver, md, data); err != nil {
+		return nil, err
+	}
+	stripHiddenFields(fieldPermissions(receiver, page), data)
+	return data, nil
+}
+
+// SignWebhook 计算推送内容的签名,接收方使用相同的secret验证X-Curd-Signature
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDispatcher 把outbox中的事件推送到页面配置的webhook,失败后按照指数退避重试,
+// 多个实例同时运行时通过条件更新保证每次推送只有一个实例执行
+type WebhookDispatcher struct {
+	Client      *http.Client
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	// Backoff 第n次失败后等待Backoff*2^(n-1)再重试,最多等待MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retention 推送完成的记录和事件保留的时间,0表示不清理
+	Retention   time.Duration
+	lastCleanup time.Time
+}
+
+// NewWebhookDispatcher 根据配置创建WebhookDispatcher,没有配置的使用默认值
+func NewWebhookDispatcher() *WebhookDispatcher {
+	cfg := config.DefaultConfig.Webhook
+	d := &WebhookDispatcher{
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		Interval:    5 * time.Second,
+		BatchSize:   100,
+		MaxAttempts: 10,
+		Backoff:     10 * time.Second,
+		MaxBackoff:  time.Hour,
+		Retention:   30 * 24 * time.Hour,
+	}
+	if cfg.Interval > 0 {
+		d.Interval = time.Duration(cfg.Interval) * time.Second
+	}
+	if cfg.Timeout > 0 {
+		d.Client.Timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	if cfg.MaxAttempts > 0 {
+		d.MaxAttempts = cfg.MaxAttempts
+	}
+	if cfg.Backoff > 0 {
+		d.Backoff = time.Duration(cfg.Backoff) * time.Second
+	}
+	if cfg.MaxBackoff > 0 {
+		d.MaxBackoff = time.Duration(cfg.MaxBackoff) * time.Second
+	}
+	if cfg.RetentionDays > 0 {
+		d.Retention = time.Duration(cfg.RetentionDays) * 24 * time.Hour
+	} else if cfg.RetentionDays < 0 {
+		d.Retention = 0
+	}
+	return d
+}
+
+// Run 每隔Interval推送一次,直到ctx结束
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		if err := d.RunOnce(ctx); err != nil {
+			log.Errorf(ctx, "推送webhook失败:%v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 为新的事件生成推送记录,然后推送所有到了重试时间的记录,每小时最多清理一次过期的记录
+func (d *WebhookDispatcher) RunOnce(ctx context.Context) error {
+	if err := d.dispatchEvents(); err != nil {
+		return err
+	}
+	if err := d.deliverPending(ctx); err != nil {
+		return err
+	}
+	if d.Retention > 0 && time.Since(d.lastCleanup) >= time.Hour {
+		d.lastCleanup = time.Now()
+		return d.cleanup(time.Now().Add(-d.Retention))
+	}
+	return nil
+}
+
+// cleanup 删除before之前推送完成的记录,以及已经生成推送记录并且没有剩余推送记录的事件,
+// 等待推送的记录和还没有生成推送记录的事件不删除
+func (d *WebhookDispatcher) cleanup(before time.Time) error {
+	err := dbClient.DB().Unscoped().Where("status <> ? AND updated_at < ?", WebhookStatusPending, before).Delete(&WebhookDelivery{}).Error
+	if err != nil {
+		return err
+	}
+	deliveries := dbClient.DB().Unscoped().Model(&WebhookDelivery{}).Select("event_id")
+	return dbClient.DB().Unscoped().Where("dispatched_at < ? AND id NOT IN (?)", before, deliveries).Delete(&OutboxEvent{}).Error
+}
+
+// dispatchEvents 按照页面当前的webhook配置为新的事件生成推送记录
+func (d *WebhookDispatcher) dispatchEvents() error {
+	var events []*OutboxEvent
+	err := dbClient.DB().Where("dispatched_at IS NULL").Order("created_at").Limit(d.BatchSize).Find(&events).Error
+	if err != nil {
+		return err
+	}
+	webhooks := make(map[string][]*PageWebhook)
+	for _, event := range events {
+		list, ok := webhooks[event.PageName]
+		if !ok {
+			page := &Page{}
+			err = dbClient.DB().Preload("Webhooks").Where("name = ?", event.PageName).Limit(1).Find(page).Error
+			if err != nil {
+				return err
+			}
+			list = page.Webhooks
+			webhooks[event.PageName] = list
+		}
+		err = dbClient.DB().Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			result := tx.Model(&OutboxEvent{}).Where("id = ? AND dispatched_at IS NULL", event.ID).Update("dispatched_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				// 已经被其他实例处理
+				return result.Error
+			}
+			for _, webhook := range list {
+				if !webhookAccepts(webhook, event.Action) {
+					continue
+				}
+				delivery := &WebhookDelivery{
+					EventID:     event.ID,
+					PageName:    event.PageName,
+					RecordID:    event.RecordID,
+					Action:      event.Action,
+					WebhookID:   webhook.ID,
+					URL:         webhook.URL,
+					Status:      WebhookStatusPending,
+					NextRetryAt: now,
+				}
+				delivery.TenantID = event.TenantID
+				if err := tx.Create(delivery).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverPending 推送到了重试时间的记录,推送前先增加Attempts占用记录,
+// 同时把NextRetryAt推迟,实例在推送过程中退出时会在推迟的时间之后重试
+func (d *WebhookDispatcher) deliverPending(ctx context.Context) error {
+	var deliveries []*WebhookDelivery
+	err := dbClient.DB().Where("status = ? AND next_retry_at <= ?", WebhookStatusPending, time.Now()).
+		Order("next_retry_at").Limit(d.BatchSize).Find(&deliveries).Error
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		attempts := delivery.Attempts + 1
+		result := dbClient.DB().Model(&WebhookDelivery{}).Where("id = ? AND status = ? AND attempts = ?", delivery.ID, WebhookStatusPending, delivery.Attempts).
+			Updates(map[string]interface{}{"attempts": attempts, "next_retry_at": time.Now().Add(d.Client.Timeout + d.backoff(attempts))})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		code, err := d.deliver(ctx, delivery)
+		values := map[string]interface{}{"response_code": code, "url": delivery.URL}
+		if err == nil {
+			now := time.Now()
+			values["status"] = WebhookStatusSuccess
+			values["last_error"] = ""
+			values["delivered_at"] = &now
+		} else {
+			message := err.Error()
+			if len(message) > 500 {
+				message = message[:500]
+			}
+			values["last_error"] = message
+			if attempts >= d.MaxAttempts {
+				values["status"] = WebhookStatusFailed
+			} else {
+				values["next_retry_at"] = time.Now().Add(d.backoff(attempts))
+			}
+		}
+		if err = dbClient.DB().Model(&WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(values).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	wait := d.Backoff
+	for i := 1; i < attempts && wait < d.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.MaxBackoff {
+		wait = d.MaxBackoff
+	}
+	return wait
+}
+
+// deliver 使用webhook当前的地址和secret推送事件,返回webhook的响应状态码,2xx表示成功
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *WebhookDelivery) (int, error) {
+	webhook := &PageWebhook{}
+	if err := dbClient.DB().Where("id = ?", delivery.WebhookID).Limit(1).Find(webhook).Error; err != nil {
+		return 0, err
+	}
+	if webhook.ID == "" || !webhook.Enable {
+		return 0, errors.New("webhook已经删除或者禁用")
+	}
+	delivery.URL = webhook.URL
+	event := &OutboxEvent{}
+	if err := dbClient.DB().Where("id = ?", delivery.EventID).First(event).Error; err != nil {
+		return 0, err
+	}
+	body, err := json.Marshal(&WebhookPayload{
+		ID:        event.ID,
+		PageName:  event.PageName,
+		Action:    event.Action,
+		RecordID:  event.RecordID,
+		TenantID:  event.TenantID,
+		UserID:    event.UserID,
+		TransID:   event.TransID,
+		Data:      event.Data,
+		CreatedAt: event.CreatedAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookHeaderEvent, event.Action)
+	req.Header.Set(WebhookHeaderPage, event.PageName)
+	req.Header.Set(WebhookHeaderDelivery, delivery.ID)
+	req.Header.Set(WebhookHeaderTimestamp, timestamp)
+	if webhook.Secret != "" {
+		req.Header.Set(WebhookHeaderSignature, SignWebhook(webhook.Secret, timestamp, body))
+	}
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return resp.StatusCode, fmt.Errorf("webhook返回%d:%s", resp.StatusCode, message)
+	}
+	return resp.StatusCode, nil
+}
+
+type WebhookDeliveryRequest struct {
+	model.CommonRequest
+	PageName string    `json:"pageName" form:"pageName" uri:"pageName"`
+	RecordID string    `json:"recordID" form:"recordID" uri:"recordID"`
+	EventID  string    `json:"eventID" form:"eventID" uri:"eventID"`
+	Status   string    `json:"status" form:"status" uri:"status"`
+	Operator *Operator `json:"-" form:"-" uri:"-"`
+}
+
+type WebhookDeliveryResponse struct {
+	model.CommonResponse
+	Data []*WebhookDelivery `json:"data"`
+}
+
+// WebhookDeliveries 分页查询页面的webhook推送记录,按时间倒序
+func WebhookDeliveries(req *WebhookDeliveryRequest, resp *WebhookDeliveryResponse) {
+	page, err := GetPageByName(req.PageName)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+		return
+	}
+	db := dbClient.DB().Model(&WebhookDelivery{}).Where("page_name = ?", page.Name)
+	if req.RecordID != "" {
+		db = db.Where("record_id = ?", req.RecordID)
+	}
+	if req.EventID != "" {
+		db = db.Where("event_id = ?", req.EventID)
+	}
+	if req.Status != "" {
+		db = db.Where("status = ?", req.Status)
+	}
+	db = ScopeTenant(db, req.Operator, page.Metadata)
+	resp.Total, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.Current, "created_at desc", &resp.Data, nil)
+	if err != nil {
+		resp.Code = model.InternalServerError
+		resp.Message = err.Error()
+	}
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+)
+
+type webhookReceiver struct {
+	lock     sync.Mutex
+	fails    int
+	payloads []*WebhookPayload
+	headers  []http.Header
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if req.URL.Path == "/gone" {
+		w.WriteHeader(http.StatusGone)
+		return
+	}
+	if r.fails > 0 {
+		r.fails--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	if req.Header.Get(WebhookHeaderSignature) != SignWebhook("s3cret", req.Header.Get(WebhookHeaderTimestamp), body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	payload := &WebhookPayload{}
+	json.Unmarshal(body, payload)
+	r.payloads = append(r.payloads, payload)
+	r.headers = append(r.headers, req.Header)
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "webhook.db"), false), true)
+
+	receiver := &webhookReceiver{fails: 1}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	setEncryptionKey(t)
+	md := &Metadata{Name: "WebhookOrder", MetadataFields: []*MetadataField{
+		{Name: "id", Type: "bigint"}, {Name: "name", Type: "varchar"}, {Name: "phone", Type: "varchar", Mask: "phone"},
+		{Name: "idCard", Type: "varchar", Encrypt: true, BlindIndexField: "idCardHash"}, {Name: "idCardHash", Type: "varchar"},
+		{Name: "salary", Type: "int"},
+	}}
+	err := dbClient.DB().Create(&Page{Name: "webhook_order", Title: "订单", Enable: true, Metadata: md, Webhooks: []*PageWebhook{
+		{URL: srv.URL + "/orders", Secret: "s3cret", Enable: true},
+		{URL: srv.URL + "/gone", Events: AuditActionDelete, Enable: true},
+	}, FieldPermissions: []*PageFieldPermission{{Field: "salary", Permission: FieldPermissionHidden}}}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = dbClient.DB().Exec("CREATE TABLE webhook_orders(id integer primary key autoincrement, name varchar(20), phone varchar(20), id_card varchar(200), id_card_hash varchar(64), salary int)").Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterHook("webhook_order", HookAfterCreate, func(ctx *HookContext) error {
+		if ctx.Data["name"] == "bad" {
+			return AbortHook("不能新增%s", ctx.Data["name"])
+		}
+		return nil
+	})
+
+	if err = Create(nil, "webhook_order", map[string]interface{}{"name": "apple", "phone": "13812341234", "idCard": "110101199001011234", "salary": 100}); err != nil {
+		t.Fatal(err)
+	}
+	// 事务回滚时不会写入事件
+	if err = Create(nil, "webhook_order", map[string]interface{}{"name": "bad"}); err == nil {
+		t.Fatal("expected hook error")
+	}
+	var count int64
+	dbClient.DB().Model(&OutboxEvent{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("expected 1 outbox event, got %d", count)
+	}
+
+	d := &WebhookDispatcher{Client: srv.Client(), BatchSize: 10, MaxAttempts: 2}
+	for i := 0; i < 2; i++ {
+		if err = d.RunOnce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(receiver.payloads) != 1 || receiver.payloads[0].Action != AuditActionCreate || receiver.payloads[0].Data["name"] != "apple" {
+		t.Fatalf("unexpected payloads %+v", receiver.payloads)
+	}
+	// 不推送加密字段、盲索引和隐藏字段,脱敏字段推送脱敏后的值
+	data := receiver.payloads[0].Data
+	if data["phone"] != "138****1234" || data["idCard"] != nil || data["idCardHash"] != nil || data["salary"] != nil {
+		t.Fatalf("unexpected payload data %v", data)
+	}
+	delivery := &WebhookDelivery{}
+	dbClient.DB().Where("page_name = ?", "webhook_order").First(delivery)
+	if delivery.Status != WebhookStatusSuccess || delivery.Attempts != 2 || delivery.ResponseCode != http.StatusOK {
+		t.Fatalf("unexpected delivery %+v", delivery)
+	}
+	if receiver.headers[0].Get(WebhookHeaderDelivery) != delivery.ID {
+		t.Fatalf("unexpected delivery header %v", receiver.headers[0])
+	}
+
+	if err = Delete(nil, "webhook_order", "1"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = d.RunOnce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(receiver.payloads) != 2 || receiver.payloads[1].Action != AuditActionDelete || receiver.payloads[1].Data["name"] != "apple" {
+		t.Fatalf("unexpected payloads %+v", receiver.payloads)
+	}
+
+	resp := &WebhookDeliveryResponse{}
+	WebhookDeliveries(&WebhookDeliveryRequest{PageName: "webhook_order", Status: WebhookStatusFailed}, resp)
+	if resp.Code != 0 || resp.Total != 1 || resp.Data[0].Attempts != 2 || resp.Data[0].ResponseCode != http.StatusGone {
+		t.Fatalf("unexpected deliveries %d %s %+v", resp.Code, resp.Message, resp.Data)
+	}
+
+	// 清理推送完成的记录和事件,等待推送的保留
+	Create(nil, "webhook_order", map[string]interface{}{"name": "pear"})
+	d.dispatchEvents()
+	if err = d.cleanup(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	var deliveries, events int64
+	dbClient.DB().Unscoped().Model(&WebhookDelivery{}).Count(&deliveries)
+	dbClient.DB().Unscoped().Model(&OutboxEvent{}).Count(&events)
+	if deliveries != 1 || events != 1 {
+		t.Fatalf("expected 1 delivery and 1 event, got %d %d", deliveries, events)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	d := &WebhookDispatcher{Backoff: 10, MaxBackoff: 50}
+	for attempts, want := range map[int]int{1: 10, 2: 20, 3: 40, 4: 50, 10: 50} {
+		if got := d.backoff(attempts); int(got) != want {
+			t.Errorf("backoff(%d): expected %d, got %d", attempts, want, got)
+		}
+	}
+}
+
+func TestCheckPageWebhooks(t *testing.T) {
+	for _, c := range []struct {
+		webhook *PageWebhook
+		valid   bool
+	}{
+		{&PageWebhook{URL: "https://example.com/hook", Events: "create, delete"}, true},
+		{&PageWebhook{URL: "ftp://example.com/hook"}, false},
+		{&PageWebhook{URL: "/hook"}, false},
+		{&PageWebhook{URL: "http://example.com", Events: "enable"}, false},
+	} {
+		if err := CheckPageWebhooks([]*PageWebhook{c.webhook}); (err == nil) != c.valid {
+			t.Errorf("%+v: unexpected result %v", c.webhook, err)
+		}
+	}
+}
+
+func TestWebhookSecret(t *testing.T) {
+	page := &Page{Metadata: &Metadata{}, Webhooks: []*PageWebhook{{URL: "https://example.com/a", Secret: "s3cret"}, {URL: "https://example.com/b"}}}
+	page.Webhooks[0].ID = "a"
+	if out := PageToPB(page); out.Webhooks[0].Secret != WebhookSecretMask || out.Webhooks[1].Secret != "" {
+		t.Fatalf("secret should be masked, got %v", out.Webhooks)
+	}
+	if out := PageToDesignPB(page); out.Webhooks[0].Secret != "s3cret" {
+		t.Fatalf("designer should get the secret, got %v", out.Webhooks)
+	}
+
+	// 传回******时保持原来的secret,原来没有的webhook清空
+	m := &Page{Webhooks: PageWebhooksToPB(PBToPageWebhooks(page.Webhooks))}
+	m.Webhooks = append(m.Webhooks, &PageWebhook{URL: "https://example.com/c", Secret: WebhookSecretMask})
+	keepWebhookSecrets(page, m)
+	if m.Webhooks[0].Secret != "s3cret" || m.Webhooks[1].Secret != "" || m.Webhooks[2].Secret != "" {
+		t.Fatalf("unexpected secrets %v", m.Webhooks)
+	}
+}
@@ -63,7 +63,7 @@ func QueryFileTemplate(req *apipb.QueryFileTemplateRequest, resp *apipb.QueryFil
 		db = db.Where("dir LIKE ?", "%"+req.Dir+"%")
 	}
 
-	orderStr, err := utils.GenerateOrderString(req.SortConfig, "`language`,`group`,`name`")
+	orderStr, err := utils.GenerateOrderString(req.SortConfig, quoteColumns([]string{"language", "group", "name"}))
 	if err != nil {
 		resp.Code = apipb.Code_BadRequest
 		resp.Message = err.Error()
@@ -94,7 +94,7 @@ func GetFileTemplateByIDs(ids []string) ([]*FileTemplate, error) {
 }
 
 func GetAllFileTemplates() (list []*FileTemplate, err error) {
-	err = dbClient.DB().Order("`language`,`group`,`name`").Find(&list).Error
+	err = dbClient.DB().Order(quoteColumns([]string{"language", "group", "name"})).Find(&list).Error
 	return
 }
 
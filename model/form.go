@@ -10,6 +10,7 @@ import (
 	"github.com/CloudSilk/pkg/model"
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Form struct {
@@ -132,16 +133,13 @@ func QueryForm(req *apipb.QueryFormRequest, resp *apipb.QueryFormResponse, prelo
 		db = db.Or("is_must = ?", req.InclusiveBasic)
 	}
 
-	OrderStr := "`updated_at` desc"
-	if req.OrderField != "" {
-		if req.Desc {
-			OrderStr = req.OrderField + " desc"
-		} else {
-			OrderStr = req.OrderField
-		}
+	OrderStr, err := OrderByModel(&Form{}, req.OrderField, req.Desc, orderClause("updated_at", true))
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
 	}
 
-	var err error
 	var forms []Form
 	if preload {
 		resp.Records, resp.Pages, err = dbClient.PageQueryWithPreload(db, req.PageSize, req.PageIndex, OrderStr, []string{"Versions"}, &forms)
@@ -218,7 +216,18 @@ func UpdateFormAll(f *Form) error {
 }
 
 func UpdateFormSchema(f *Form) error {
-	return dbClient.DB().Model(f).Where("id", f.ID).Update("schema", f.Schema).Error
+	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
+		old := &Form{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "updated_at").Where("id = ?", f.ID).First(old).Error
+		if err != nil {
+			return err
+		}
+		err = CheckUnixUpdatedAt(old.UpdatedAt, f.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		return tx.Model(old).Updates(map[string]interface{}{"schema": f.Schema, "updated_at": NextUnixUpdatedAt(old.UpdatedAt)}).Error
+	})
 }
 
 func CopyForm(id string) error {
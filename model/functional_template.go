@@ -61,7 +61,7 @@ func QueryFunctionalTemplate(req *apipb.QueryFunctionalTemplateRequest, resp *ap
 		db = db.Where("name LIKE ?", "%"+req.Name+"%")
 	}
 
-	orderStr, err := utils.GenerateOrderString(req.SortConfig, "`name`")
+	orderStr, err := utils.GenerateOrderString(req.SortConfig, orderClause("name", false))
 	if err != nil {
 		resp.Code = apipb.Code_BadRequest
 		resp.Message = err.Error()
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+var schemaCache = &sync.Map{}
+
+// QuoteColumn 按当前数据库的方言给表名或者字段名加上引号
+func QuoteColumn(name string) string {
+	return dbClient.DB().Statement.Quote(name)
+}
+
+// quoteColumns 给多个字段名加上引号,使用逗号隔开
+func quoteColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = QuoteColumn(column)
+	}
+	return strings.Join(quoted, ",")
+}
+
+func orderClause(column string, desc bool) string {
+	if desc {
+		return QuoteColumn(column) + " desc"
+	}
+	return QuoteColumn(column)
+}
+
+// OrderByMetadata 排序字段必须是元数据中定义的字段,否则返回错误,
+// 当前用户不能查看原值的脱敏字段不能排序,避免通过顺序推测出原值
+func OrderByMetadata(op *Operator, md *Metadata, orderField string, desc bool, defaultOrder string) (string, error) {
+	if orderField == "" {
+		return defaultOrder, nil
+	}
+	field := md.FieldByName(orderField)
+	if field == nil {
+		return "", fmt.Errorf("不存在排序字段:%s", orderField)
+	}
+	if field.Mask != "" && !canUnmask(op, field) {
+		return "", fmt.Errorf("脱敏字段%s不能排序", orderField)
+	}
+	return orderClause(LowerSnakeCase(field.Name), desc), nil
+}
+
+// OrderByModel 排序字段必须是value对应的表中的字段,否则返回错误
+func OrderByModel(value interface{}, orderField string, desc bool, defaultOrder string) (string, error) {
+	if orderField == "" {
+		return defaultOrder, nil
+	}
+	s, err := schema.Parse(value, schemaCache, dbClient.DB().NamingStrategy)
+	if err != nil {
+		return "", err
+	}
+	name := strings.Trim(orderField, "`\" ")
+	field := s.LookUpField(name)
+	if field == nil {
+		field = s.LookUpField(LowerSnakeCase(name))
+	}
+	if field == nil || field.DBName == "" {
+		return "", fmt.Errorf("不存在排序字段:%s", orderField)
+	}
+	return orderClause(field.DBName, desc), nil
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOrderBy(t *testing.T) {
+	md := &Metadata{MetadataFields: []*MetadataField{{Name: "name"}, {Name: "createdAt"}}}
+	order, err := OrderByMetadata(nil, md, "createdAt", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(order, "created_at") || !strings.HasSuffix(order, " desc") {
+		t.Fatalf("unexpected order %s", order)
+	}
+	if _, err = OrderByMetadata(nil, md, "name;drop table pages", false, ""); err == nil {
+		t.Fatal("expected error")
+	}
+
+	order, err = OrderByModel(&Page{}, "updatedAt", false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(order, "updated_at") {
+		t.Fatalf("unexpected order %s", order)
+	}
+	if _, err = OrderByModel(&Page{}, "(select 1)", false, ""); err == nil {
+		t.Fatal("expected error")
+	}
+}
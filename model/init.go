@@ -32,7 +32,7 @@ func InitDB(client db.DBClientInterface, debug bool) {
 
 // AutoMigrate 自动生成表
 func AutoMigrate() {
-	dbClient.DB().AutoMigrate(&Metadata{}, &MetadataField{}, &Page{}, &PageToolBar{}, &PageField{}, &PageButton{}, &Template{},
+	dbClient.DB().AutoMigrate(&Metadata{}, &MetadataField{}, &Page{}, &PageToolBar{}, &PageField{}, &PageButton{}, &PageHook{}, &PageWebhook{}, &PageDataRule{}, &PageFieldPermission{}, &CodeSequence{}, &Template{},
 		&Service{}, &CodeFile{}, &ServiceFunctional{}, &Cell{}, &CellMarkup{}, &CellAttrs{}, &CellConnecting{}, &Form{}, &FormVersion{}, &FileTemplate{},
-		&FunctionalTemplate{}, &SystemObject{})
+		&FunctionalTemplate{}, &SystemObject{}, &AuditLog{}, &OutboxEvent{}, &WebhookDelivery{})
 }
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	apipb "github.com/CloudSilk/curd/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	VersionColumn   = "version"
+	UpdatedAtColumn = "updated_at"
+	CreatedAtColumn = "created_at"
+)
+
+// ErrRecordChanged 乐观锁检查失败,前端可以根据RecordChanged提示用户刷新后重试
+var ErrRecordChanged = errors.New("记录已经被其他人修改,请刷新后重试")
+
+// ErrorCode 返回错误对应的返回码
+func ErrorCode(err error, defaultCode apipb.Code) apipb.Code {
+	if errors.Is(err, ErrRecordChanged) {
+		return apipb.Code_RecordChanged
+	}
+	if errors.Is(err, ErrNoDataPermission) {
+		return apipb.Code_NoPermission
+	}
+	if errors.Is(err, ErrEnableNotSupported) || errors.Is(err, ErrSoftDeleteNotSupported) {
+		return apipb.Code_BadRequest
+	}
+	var hookErr *HookError
+	if errors.As(err, &hookErr) {
+		return apipb.Code_BadRequest
+	}
+	return defaultCode
+}
+
+// UpdatedAtPrecision 数据库中时间字段的最大精度,MySQL和PostgreSQL最多只保存到微秒
+const UpdatedAtPrecision = time.Microsecond
+
+// CheckUpdatedAt 检查客户端读取记录时的更新时间和数据库中的是否一致,expected为零值时不检查
+// 按数据库保存的精度比较,同一秒内的两次修改也能检查出来
+func CheckUpdatedAt(current, expected time.Time) error {
+	if expected.IsZero() {
+		return nil
+	}
+	if !current.Truncate(UpdatedAtPrecision).Equal(expected.Truncate(UpdatedAtPrecision)) {
+		return ErrRecordChanged
+	}
+	return nil
+}
+
+// CheckUnixUpdatedAt PB中的更新时间只精确到秒,只能按秒比较。
+// 保存时需要用NextUnixUpdatedAt更新时间,保证每次修改后更新时间的秒数都会变化
+func CheckUnixUpdatedAt(current, expected time.Time) error {
+	if expected.IsZero() {
+		return nil
+	}
+	if current.Unix() != expected.Unix() {
+		return ErrRecordChanged
+	}
+	return nil
+}
+
+// NextUnixUpdatedAt 返回按秒比较的记录修改后的更新时间,
+// 同一秒内再次修改时顺延到下一秒,否则客户端拿着同一秒的旧时间也能通过检查
+func NextUnixUpdatedAt(current time.Time) time.Time {
+	next := time.Now().Truncate(time.Second)
+	if min := current.Truncate(time.Second).Add(time.Second); next.Before(min) {
+		next = min
+	}
+	return next
+}
+
+// UnixTime PB中的时间是秒,0表示没有传值
+func UnixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// checkRecordVersion 元数据中定义了Version字段时使用版本号,否则使用UpdatedAt字段检查记录是否被修改
+// m中没有传版本号或者更新时间时不检查
+func checkRecordVersion(md *Metadata, current, m map[string]interface{}) error {
+	if field := md.FieldByName(VersionColumn); field != nil {
+		expected := derefValue(m[field.Name])
+		if expected == nil {
+			return nil
+		}
+		expectedVersion, err := toInt64(expected)
+		if err != nil {
+			return err
+		}
+		currentVersion, _ := toInt64(derefValue(current[VersionColumn]))
+		if currentVersion != expectedVersion {
+			return ErrRecordChanged
+		}
+		return nil
+	}
+	if field := md.FieldByName(UpdatedAtColumn); field != nil {
+		expected := derefValue(m[field.Name])
+		if expected == nil {
+			return nil
+		}
+		expectedTime, err := toTime(expected)
+		if err != nil {
+			return err
+		}
+		currentTime := time.Time{}
+		if t, err := toTime(derefValue(current[UpdatedAtColumn])); err == nil {
+			currentTime = t.(time.Time)
+		}
+		return CheckUpdatedAt(currentTime, expectedTime.(time.Time))
+	}
+	return nil
+}
+
+// lockRecord 在事务中读取并锁定需要修改的记录
+func lockRecord(tx *gorm.DB, op *Operator, page *Page, id interface{}) (map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	err := recordDB(tx, op, page).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Limit(1).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrRecordNotExist
+	}
+	return rows[0], nil
+}
+
+// touchRecord 修改记录时增加版本号和更新时间
+func touchRecord(md *Metadata, values map[string]interface{}) {
+	if md.FieldByName(VersionColumn) != nil {
+		values[VersionColumn] = gorm.Expr("COALESCE(" + QuoteColumn(VersionColumn) + ",0) + 1")
+	}
+	if md.FieldByName(UpdatedAtColumn) != nil {
+		values[UpdatedAtColumn] = time.Now()
+	}
+}
+
+// initRecord 新增记录时初始化版本号、创建时间和更新时间
+func initRecord(md *Metadata, m map[string]interface{}) {
+	if field := md.FieldByName(VersionColumn); field != nil {
+		m[field.Name] = 0
+	}
+	now := time.Now()
+	for _, column := range []string{CreatedAtColumn, UpdatedAtColumn} {
+		if field := md.FieldByName(column); field != nil {
+			m[field.Name] = now
+		}
+	}
+}
+
+// isLockColumn 版本号和更新时间由系统维护,不能直接修改
+func isLockColumn(column string) bool {
+	return column == VersionColumn || column == UpdatedAtColumn
+}
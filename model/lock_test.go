@@ -0,0 +1,83 @@
+package model
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sqliteclient "github.com/CloudSilk/pkg/db/sqlite"
+	"github.com/CloudSilk/pkg/model"
+)
+
+func TestCheckRecordVersion(t *testing.T) {
+	now := time.Now()
+	versioned := &Metadata{MetadataFields: []*MetadataField{{Name: "version"}, {Name: "updatedAt"}}}
+	current := map[string]interface{}{"version": int64(2), "updated_at": &now}
+
+	if err := checkRecordVersion(versioned, current, map[string]interface{}{"version": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRecordVersion(versioned, current, map[string]interface{}{"version": float64(1)}); err != ErrRecordChanged {
+		t.Fatalf("expected ErrRecordChanged, got %v", err)
+	}
+	if err := checkRecordVersion(versioned, current, map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	timed := &Metadata{MetadataFields: []*MetadataField{{Name: "updatedAt"}}}
+	if err := checkRecordVersion(timed, current, map[string]interface{}{"updatedAt": now.Format(time.RFC3339Nano)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkRecordVersion(timed, current, map[string]interface{}{"updatedAt": now.Add(-time.Minute)}); err != ErrRecordChanged {
+		t.Fatalf("expected ErrRecordChanged, got %v", err)
+	}
+
+	// 同一秒内的修改
+	second := now.Truncate(time.Second)
+	changed := second.Add(500 * time.Millisecond)
+	if err := checkRecordVersion(timed, map[string]interface{}{"updated_at": changed}, map[string]interface{}{"updatedAt": second.Add(100 * time.Millisecond).Format(time.RFC3339Nano)}); err != ErrRecordChanged {
+		t.Fatalf("expected ErrRecordChanged, got %v", err)
+	}
+
+	if err := CheckUpdatedAt(now, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckUpdatedAt(now, now.Add(time.Nanosecond).Truncate(UpdatedAtPrecision)); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckUnixUpdatedAt(now, UnixTime(now.Unix())); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNextUnixUpdatedAt(t *testing.T) {
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	if next := NextUnixUpdatedAt(future.Add(300 * time.Millisecond)); !next.Equal(future.Add(time.Second)) {
+		t.Fatalf("unexpected next updatedAt %v", next)
+	}
+	past := time.Now().Add(-time.Hour)
+	if next := NextUnixUpdatedAt(past); next.Unix() == past.Unix() || next.Nanosecond() != 0 {
+		t.Fatalf("unexpected next updatedAt %v", next)
+	}
+}
+
+func TestUpdateFormSchemaSameSecond(t *testing.T) {
+	old := dbClient
+	defer func() { dbClient = old }()
+	InitDB(sqliteclient.NewSqlite(filepath.Join(t.TempDir(), "form.db"), false), true)
+
+	f := &Form{TenantModel: model.TenantModel{Model: model.Model{ID: "form-1"}}, Name: "form", Schema: "{}"}
+	if err := dbClient.DB().Create(f).Error; err != nil {
+		t.Fatal(err)
+	}
+	f.UpdatedAt = UnixTime(f.UpdatedAt.Unix())
+	if err := UpdateFormSchema(&Form{TenantModel: f.TenantModel, Schema: `{"a":1}`}); err != nil {
+		t.Fatal(err)
+	}
+	// 两个人读取的是同一个版本,第二个人在同一秒内保存也要失败
+	err := UpdateFormSchema(&Form{TenantModel: f.TenantModel, Schema: `{"b":2}`})
+	if !errors.Is(err, ErrRecordChanged) {
+		t.Fatalf("expected ErrRecordChanged, got %v", err)
+	}
+}
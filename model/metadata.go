@@ -28,6 +28,7 @@ type Metadata struct {
 	Preloads       []string         `json:"-" gorm:"-"`
 	System         string           `json:"system" gorm:"index;size:100"`
 	IsMust         bool             `json:"isMust" gorm:"index;comment:系统必须要有的数据"`
+	IDStrategy     string           `json:"idStrategy" gorm:"size:20;comment:主键生成方式:auto、uuid、snowflake"`
 }
 
 func (md *Metadata) Sort() {
@@ -69,6 +70,14 @@ type MetadataField struct {
 	DotNotGen    bool
 	PBToStruct   string `json:"pbToStruct" gorm:"size:100;"`
 	StructToPB   string `json:"structToPB" gorm:"size:100;"`
+	// Encrypt 使用AES-GCM加密保存,数据库字段的长度需要能保存加密后的内容
+	Encrypt         bool   `json:"encrypt" gorm:"comment:加密保存"`
+	BlindIndexField string `json:"blindIndexField" gorm:"size:100;comment:保存盲索引的字段,用于加密字段的精确查询"`
+	// Mask 脱敏规则,可以是phone、idCard、bankCard、name或者"前面保留位数,后面保留位数"
+	Mask        string `json:"mask" gorm:"size:50;comment:脱敏规则"`
+	UnmaskRoles string `json:"unmaskRoles" gorm:"size:500;comment:可以查看明文的角色ID,多个使用逗号隔开"`
+	// CodeRule 编码规则,例如PO-{yyyyMMdd}-{seq:4},新增时没有传入值则自动生成
+	CodeRule string `json:"codeRule" gorm:"size:100;comment:编码规则"`
 }
 
 func CreateMetadata(md *Metadata) error {
@@ -80,6 +89,15 @@ func CreateMetadata(md *Metadata) error {
 		md.Level = parent.Level + 1
 	}
 	md.FieldSort()
+	if err := CheckSensitiveFields(md); err != nil {
+		return err
+	}
+	if err := CheckIDStrategy(md); err != nil {
+		return err
+	}
+	if err := CheckCodeRules(md); err != nil {
+		return err
+	}
 	duplication, err := dbClient.CreateWithCheckDuplication(md, "`system`=? and name = ? and project_id=? and tenant_id=?", md.System, md.Name, md.ProjectID, md.TenantID)
 	if err != nil {
 		return err
@@ -91,6 +109,7 @@ func CreateMetadata(md *Metadata) error {
 }
 
 func DeleteMetadata(id string) (err error) {
+	refMetadataCache.Delete(id)
 	return dbClient.DB().Delete(&Metadata{}, "id=?", id).Error
 }
 
@@ -116,15 +135,12 @@ func QueryMetadata(req *apipb.QueryMetadataRequest, resp *apipb.QueryMetadataRes
 		db = db.Where("id in ?", req.Ids)
 	}
 
-	OrderStr := "`name`"
-	if req.OrderField != "" {
-		if req.Desc {
-			OrderStr = req.OrderField + " desc"
-		} else {
-			OrderStr = req.OrderField
-		}
+	OrderStr, err := OrderByModel(&Metadata{}, req.OrderField, req.Desc, orderClause("name", false))
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
 	}
-	var err error
 	var metadatas []*Metadata
 	if preload {
 		resp.Records, resp.Pages, err = dbClient.PageQueryWithPreload(db, req.PageSize, req.PageIndex, OrderStr, []string{"MetadataFields", clause.Associations}, &metadatas)
@@ -164,7 +180,7 @@ func GetMetadataById(id string) (*Metadata, error) {
 
 func GetMetadataFieldByMDId(mdID string) ([]*MetadataField, error) {
 	var fields []*MetadataField
-	err := dbClient.DB().Order("`order`").Model(&MetadataField{}).Where("metadata_id = ?", mdID).Find(&fields).Error
+	err := dbClient.DB().Order(QuoteColumn("order")).Model(&MetadataField{}).Where("metadata_id = ?", mdID).Find(&fields).Error
 	return fields, err
 }
 
@@ -175,6 +191,7 @@ func GetMetadataByName(name string) (*Metadata, error) {
 }
 
 func UpdateMetadata(md *Metadata) error {
+	refMetadataCache.Delete(md.ID)
 	if md.ParentID != "" {
 		parent, err := GetMetadataById(md.ParentID)
 		if err != nil {
@@ -183,6 +200,15 @@ func UpdateMetadata(md *Metadata) error {
 		md.Level = parent.Level + 1
 	}
 	md.FieldSort()
+	if err := CheckSensitiveFields(md); err != nil {
+		return err
+	}
+	if err := CheckIDStrategy(md); err != nil {
+		return err
+	}
+	if err := CheckCodeRules(md); err != nil {
+		return err
+	}
 	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
 		oldMetadata := &Metadata{}
 		err := tx.Preload("MetadataFields").Preload(clause.Associations).Where("id = ?", md.ID).First(oldMetadata).Error
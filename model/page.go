@@ -111,6 +111,14 @@ type Page struct {
 	IsChild  bool   `json:"isChild" gorm:"index;comment:子列表，应用于左右分栏"`
 	Children string `json:"children" gorm:"size:200;comment:子列表的PageName,多个使用逗号隔开，应用于左右分栏等"`
 	Bordered bool   `json:"bordered" gorm:"comment:是否显示边框"`
+
+	EnableAudit bool `json:"enableAudit" gorm:"comment:是否记录数据变更历史"`
+
+	Hooks     []*PageHook     `json:"hooks"`
+	Webhooks  []*PageWebhook  `json:"webhooks"`
+	DataRules []*PageDataRule `json:"dataRules"`
+	// FieldPermissions 按角色控制字段的读写权限
+	FieldPermissions []*PageFieldPermission `json:"fieldPermissions"`
 }
 
 type PageField struct {
@@ -185,6 +193,78 @@ func (u *PageButton) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+// PageHook 服务端执行的钩子,在通用增删改查接口的事务中执行
+type PageHook struct {
+	ID         string `json:"id" copier:"-"`
+	PageID     string `json:"pageID" gorm:"" copier:"-"`
+	Event      string `json:"event" gorm:"size:20;comment:beforeCreate,afterCreate,beforeUpdate,afterUpdate,beforeDelete,afterDelete"`
+	Type       string `json:"type" gorm:"size:20;comment:validate-校验 set-计算字段"`
+	Field      string `json:"field" gorm:"size:100;comment:Type为set时保存计算结果的字段,Type为validate时校验失败的字段"`
+	Expression string `json:"expression" gorm:"size:1000"`
+	Message    string `json:"message" gorm:"size:200;comment:校验失败时的提示"`
+	Index      int32  `json:"index"`
+	Enable     bool   `json:"enable" gorm:"comment:是否启用"`
+}
+
+func (u *PageHook) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	return
+}
+
+// PageWebhook 记录新增、修改、删除后推送的地址,由WebhookDispatcher异步推送
+type PageWebhook struct {
+	ID     string `json:"id" copier:"-"`
+	PageID string `json:"pageID" gorm:"" copier:"-"`
+	Name   string `json:"name" gorm:"size:100"`
+	URL    string `json:"url" gorm:"size:500"`
+	Secret string `json:"secret" gorm:"size:200;comment:用于HMAC-SHA256签名,为空时不签名"`
+	Events string `json:"events" gorm:"size:100;comment:create,update,delete,多个使用逗号隔开,为空时推送所有事件"`
+	Enable bool   `json:"enable" gorm:"comment:是否启用"`
+}
+
+func (u *PageWebhook) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	return
+}
+
+// PageDataRule 行级数据权限规则,同一个角色的规则之间是AND关系,不同角色之间是OR关系
+type PageDataRule struct {
+	ID          string `json:"id" copier:"-"`
+	PageID      string `json:"pageID" gorm:"" copier:"-"`
+	RoleID      string `json:"roleID" gorm:"size:36;comment:usercenter的角色ID,为空时对所有用户生效"`
+	Rule        string `json:"rule" gorm:"size:500;comment:例如owner = currentUser、dept in currentUser.depts、status != archived"`
+	Description string `json:"description" gorm:"size:200"`
+	Index       int32  `json:"index"`
+	Enable      bool   `json:"enable" gorm:"comment:是否启用"`
+}
+
+func (u *PageDataRule) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	return
+}
+
+// PageFieldPermission 字段权限,角色为空的配置作为没有单独配置的角色的默认权限
+type PageFieldPermission struct {
+	ID         string `json:"id" copier:"-"`
+	PageID     string `json:"pageID" gorm:"" copier:"-"`
+	RoleID     string `json:"roleID" gorm:"size:36;comment:usercenter的角色ID,为空时对所有用户生效"`
+	Field      string `json:"field" gorm:"size:100;comment:元数据字段名"`
+	Permission string `json:"permission" gorm:"size:20;comment:hidden、readonly或者readwrite"`
+}
+
+func (u *PageFieldPermission) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	return
+}
+
 func SortFields(fields []*PageField) {
 	for i, field := range fields {
 		field.Sort = int32(i) + 1
@@ -197,9 +277,36 @@ func SortButtons(buttons []*PageButton) {
 	})
 }
 
+func SortHooks(hooks []*PageHook) {
+	sort.Slice(hooks, func(i, j int) bool {
+		return hooks[i].Index < hooks[j].Index
+	})
+}
+
+func SortDataRules(rules []*PageDataRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Index < rules[j].Index
+	})
+}
+
 func CreatePage(m *Page) error {
 	SortFields(m.Fields)
 	SortButtons(m.Buttons)
+	SortHooks(m.Hooks)
+	SortDataRules(m.DataRules)
+	keepWebhookSecrets(nil, m)
+	if err := CheckPageHooks(m.Hooks); err != nil {
+		return err
+	}
+	if err := CheckPageWebhooks(m.Webhooks); err != nil {
+		return err
+	}
+	if err := CheckPageDataRules(m.DataRules); err != nil {
+		return err
+	}
+	if err := CheckPageFieldPermissions(m.FieldPermissions); err != nil {
+		return err
+	}
 	count, err := statisticPageCount(dbClient.DB(), m.TenantID, m.ProjectID)
 	if err != nil {
 		return err
@@ -275,15 +382,126 @@ func DeleteButtons(tx *gorm.DB, old, m *Page) error {
 	return nil
 }
 
+func DeleteHooks(tx *gorm.DB, old, m *Page) error {
+	var deleteIDs []string
+	for _, oldObj := range old.Hooks {
+		flag := false
+		for _, newObj := range m.Hooks {
+			if newObj.ID == oldObj.ID {
+				flag = true
+			}
+		}
+		if !flag {
+			deleteIDs = append(deleteIDs, oldObj.ID)
+		}
+	}
+
+	if len(deleteIDs) > 0 {
+		err := tx.Unscoped().Delete(&PageHook{}, "id in ?", deleteIDs).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DeleteWebhooks(tx *gorm.DB, old, m *Page) error {
+	var deleteIDs []string
+	for _, oldObj := range old.Webhooks {
+		flag := false
+		for _, newObj := range m.Webhooks {
+			if newObj.ID == oldObj.ID {
+				flag = true
+			}
+		}
+		if !flag {
+			deleteIDs = append(deleteIDs, oldObj.ID)
+		}
+	}
+
+	if len(deleteIDs) > 0 {
+		err := tx.Unscoped().Delete(&PageWebhook{}, "id in ?", deleteIDs).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DeleteDataRules(tx *gorm.DB, old, m *Page) error {
+	var deleteIDs []string
+	for _, oldObj := range old.DataRules {
+		flag := false
+		for _, newObj := range m.DataRules {
+			if newObj.ID == oldObj.ID {
+				flag = true
+			}
+		}
+		if !flag {
+			deleteIDs = append(deleteIDs, oldObj.ID)
+		}
+	}
+
+	if len(deleteIDs) > 0 {
+		err := tx.Unscoped().Delete(&PageDataRule{}, "id in ?", deleteIDs).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DeleteFieldPermissions(tx *gorm.DB, old, m *Page) error {
+	var deleteIDs []string
+	for _, oldObj := range old.FieldPermissions {
+		flag := false
+		for _, newObj := range m.FieldPermissions {
+			if newObj.ID == oldObj.ID {
+				flag = true
+			}
+		}
+		if !flag {
+			deleteIDs = append(deleteIDs, oldObj.ID)
+		}
+	}
+
+	if len(deleteIDs) > 0 {
+		err := tx.Unscoped().Delete(&PageFieldPermission{}, "id in ?", deleteIDs).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func UpdatePage(m *Page) error {
 	SortFields(m.Fields)
 	SortButtons(m.Buttons)
+	SortHooks(m.Hooks)
+	SortDataRules(m.DataRules)
+	if err := CheckPageHooks(m.Hooks); err != nil {
+		return err
+	}
+	if err := CheckPageWebhooks(m.Webhooks); err != nil {
+		return err
+	}
+	if err := CheckPageDataRules(m.DataRules); err != nil {
+		return err
+	}
+	if err := CheckPageFieldPermissions(m.FieldPermissions); err != nil {
+		return err
+	}
 	return dbClient.DB().Transaction(func(tx *gorm.DB) error {
 		oldPage := &Page{}
-		err := tx.Preload("Fields").Preload(clause.Associations).Where("id = ?", m.ID).First(oldPage).Error
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Fields").Preload(clause.Associations).Where("id = ?", m.ID).First(oldPage).Error
 		if err != nil {
 			return err
 		}
+		err = CheckUnixUpdatedAt(oldPage.UpdatedAt, m.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		keepWebhookSecrets(oldPage, m)
 
 		err = DeleteFields(tx, oldPage, m)
 		if err != nil {
@@ -295,6 +513,26 @@ func UpdatePage(m *Page) error {
 			return err
 		}
 
+		err = DeleteHooks(tx, oldPage, m)
+		if err != nil {
+			return err
+		}
+
+		err = DeleteWebhooks(tx, oldPage, m)
+		if err != nil {
+			return err
+		}
+
+		err = DeleteDataRules(tx, oldPage, m)
+		if err != nil {
+			return err
+		}
+
+		err = DeleteFieldPermissions(tx, oldPage, m)
+		if err != nil {
+			return err
+		}
+
 		duplication, err := dbClient.UpdateWithCheckDuplicationAndOmit(tx, m, true, []string{"created_at"}, "id != ?  and  name =? and project_id=?", m.ID, m.Name, m.ProjectID)
 		if err != nil {
 			return err
@@ -303,7 +541,7 @@ func UpdatePage(m *Page) error {
 			return errors.New("存在相同页面配置")
 		}
 
-		return nil
+		return tx.Model(&Page{}).Where("id = ?", m.ID).UpdateColumn("updated_at", NextUnixUpdatedAt(oldPage.UpdatedAt)).Error
 	})
 }
 
@@ -339,15 +577,12 @@ func QueryPage(req *apipb.QueryPageRequest, resp *apipb.QueryPageResponse, prelo
 		db = db.Or("is_must = ?", req.InclusiveBasic)
 	}
 
-	OrderStr := "`updated_at` desc"
-	if req.OrderField != "" {
-		if req.Desc {
-			OrderStr = req.OrderField + " desc"
-		} else {
-			OrderStr = req.OrderField
-		}
+	OrderStr, err := OrderByModel(&Page{}, req.OrderField, req.Desc, orderClause("updated_at", true))
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
 	}
-	var err error
 	var pages []*Page
 	if preload {
 		resp.Records, resp.Pages, err = dbClient.PageQueryWithPreload(db, req.PageSize, req.PageIndex, OrderStr, []string{"Metadata.MetadataFields", "Fields", clause.Associations}, &pages)
@@ -365,6 +600,8 @@ func QueryPage(req *apipb.QueryPageRequest, resp *apipb.QueryPageResponse, prelo
 					return m.Fields[i].Sort < m.Fields[j].Sort
 				})
 				SortButtons(m.Buttons)
+				SortHooks(m.Hooks)
+				SortDataRules(m.DataRules)
 			}
 		}
 		resp.Data = PagesToPB(pages)
@@ -396,6 +633,8 @@ func GetPageByID(id string) (*Page, error) {
 		return m.Fields[i].Sort < m.Fields[j].Sort
 	})
 	SortButtons(m.Buttons)
+	SortHooks(m.Hooks)
+	SortDataRules(m.DataRules)
 	return m, err
 }
 
@@ -406,6 +645,8 @@ func GetPageByName(name string) (*Page, error) {
 		return m.Fields[i].Sort < m.Fields[j].Sort
 	})
 	SortButtons(m.Buttons)
+	SortHooks(m.Hooks)
+	SortDataRules(m.DataRules)
 	return m, err
 }
 
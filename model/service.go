@@ -98,15 +98,12 @@ func QueryService(req *apipb.QueryServiceRequest, resp *apipb.QueryServiceRespon
 		db = db.Where("project_id = ?", req.ProjectID)
 	}
 
-	orderStr := "`name`"
-	if req.OrderField != "" {
-		if req.Desc {
-			orderStr = req.OrderField + " desc"
-		} else {
-			orderStr = req.OrderField
-		}
+	orderStr, err := OrderByModel(&Service{}, req.OrderField, req.Desc, orderClause("name", false))
+	if err != nil {
+		resp.Code = apipb.Code_BadRequest
+		resp.Message = err.Error()
+		return
 	}
-	var err error
 	var list []*Service
 	resp.Records, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.PageIndex, orderStr, &list, nil)
 	if err != nil {
@@ -51,7 +51,7 @@ func QuerySystemObject(req *apipb.QuerySystemObjectRequest, resp *apipb.QuerySys
 		db = db.Where("type = ?", req.Type)
 	}
 
-	orderStr, err := utils.GenerateOrderString(req.SortConfig, "`name`")
+	orderStr, err := utils.GenerateOrderString(req.SortConfig, orderClause("name", false))
 	if err != nil {
 		resp.Code = apipb.Code_BadRequest
 		resp.Message = err.Error()
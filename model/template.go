@@ -57,15 +57,12 @@ func QueryTemplate(req *apipb.QueryTemplateRequest, resp *apipb.QueryTemplateRes
 		db = db.Where("id LIKE ?", "%"+req.Id+"%")
 	}
 
-	OrderStr := "language,`group`,name"
-	if req.OrderField != "" {
-		if req.Desc {
-			OrderStr = req.OrderField + " desc"
-		} else {
-			OrderStr = req.OrderField
-		}
-	}
-	var err error
+	OrderStr, err := OrderByModel(&Template{}, req.OrderField, req.Desc, quoteColumns([]string{"language", "group", "name"}))
+	if err != nil {
+		resp.Code = model.BadRequest
+		resp.Message = err.Error()
+		return
+	}
 	var result []*Template
 	resp.Records, resp.Pages, err = dbClient.PageQuery(db, req.PageSize, req.PageIndex, OrderStr, &result, nil)
 	if err != nil {
@@ -82,7 +79,7 @@ func GetAllTemplates(req *apipb.QueryTemplateRequest) (tpls []*Template, err err
 	if req.TenantID != "" {
 		db = db.Where("tenant_id = ?", req.TenantID)
 	}
-	err = db.Order("language,`group`,name").Find(&tpls).Error
+	err = db.Order(quoteColumns([]string{"language", "group", "name"})).Find(&tpls).Error
 	return
 }
 
@@ -34,6 +34,8 @@ const (
 	Code_Unauthorized Code = 40001
 	// 资源不存在
 	Code_ErrRecordNotFound Code = 40002
+	// 记录已经被其他人修改
+	Code_RecordChanged Code = 40003
 	// 用户名或者密码错误
 	Code_UserNameOrPasswordIsWrong Code = 41001
 	// 用户不存在
@@ -57,6 +59,7 @@ var (
 		40000: "BadRequest",
 		40001: "Unauthorized",
 		40002: "ErrRecordNotFound",
+		40003: "RecordChanged",
 		41001: "UserNameOrPasswordIsWrong",
 		41002: "UserIsNotExist",
 		41003: "NoPermission",
@@ -71,6 +74,7 @@ var (
 		"BadRequest":                40000,
 		"Unauthorized":              40001,
 		"ErrRecordNotFound":         40002,
+		"RecordChanged":             40003,
 		"UserNameOrPasswordIsWrong": 41001,
 		"UserIsNotExist":            41002,
 		"NoPermission":              41003,
@@ -396,7 +400,7 @@ var file_curd_common_proto_rawDesc = []byte{
 	0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6e,
 	0x61, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e,
-	0x61, 0x6e, 0x74, 0x49, 0x44, 0x2a, 0x80, 0x02, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x08,
+	0x61, 0x6e, 0x74, 0x49, 0x44, 0x2a, 0x95, 0x02, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x08,
 	0x0a, 0x04, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63,
 	0x65, 0x73, 0x73, 0x10, 0xa0, 0x9c, 0x01, 0x12, 0x19, 0x0a, 0x13, 0x49, 0x6e, 0x74, 0x65, 0x72,
 	0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0xd0,
@@ -404,18 +408,19 @@ var file_curd_common_proto_rawDesc = []byte{
 	0x10, 0xc0, 0xb8, 0x02, 0x12, 0x12, 0x0a, 0x0c, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
 	0x69, 0x7a, 0x65, 0x64, 0x10, 0xc1, 0xb8, 0x02, 0x12, 0x17, 0x0a, 0x11, 0x45, 0x72, 0x72, 0x52,
 	0x65, 0x63, 0x6f, 0x72, 0x64, 0x4e, 0x6f, 0x74, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x10, 0xc2, 0xb8,
-	0x02, 0x12, 0x1f, 0x0a, 0x19, 0x55, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x4f, 0x72, 0x50,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x73, 0x57, 0x72, 0x6f, 0x6e, 0x67, 0x10, 0xa9,
-	0xc0, 0x02, 0x12, 0x14, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x73, 0x4e, 0x6f, 0x74, 0x45,
-	0x78, 0x69, 0x73, 0x74, 0x10, 0xaa, 0xc0, 0x02, 0x12, 0x12, 0x0a, 0x0c, 0x4e, 0x6f, 0x50, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0xab, 0xc0, 0x02, 0x12, 0x12, 0x0a, 0x0c,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x10, 0xac, 0xc0, 0x02,
-	0x12, 0x12, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64,
-	0x10, 0xad, 0xc0, 0x02, 0x12, 0x12, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x10, 0xae, 0xc0, 0x02, 0x42, 0x27, 0x0a, 0x0d, 0x63, 0x6e, 0x2e, 0x61,
-	0x74, 0x61, 0x6c, 0x69, 0x2e, 0x63, 0x75, 0x72, 0x64, 0x42, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x07, 0x2e, 0x2f, 0x3b, 0x63, 0x75, 0x72,
-	0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x02, 0x12, 0x13, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x10, 0xc3, 0xb8, 0x02, 0x12, 0x1f, 0x0a, 0x19, 0x55, 0x73, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x4f, 0x72, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x49, 0x73, 0x57, 0x72,
+	0x6f, 0x6e, 0x67, 0x10, 0xa9, 0xc0, 0x02, 0x12, 0x14, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x73, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x10, 0xaa, 0xc0, 0x02, 0x12, 0x12, 0x0a,
+	0x0c, 0x4e, 0x6f, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x10, 0xab, 0xc0,
+	0x02, 0x12, 0x12, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x10, 0xac, 0xc0, 0x02, 0x12, 0x12, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x64, 0x10, 0xad, 0xc0, 0x02, 0x12, 0x12, 0x0a, 0x0c, 0x55, 0x73, 0x65,
+	0x72, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x10, 0xae, 0xc0, 0x02, 0x42, 0x27, 0x0a,
+	0x0d, 0x63, 0x6e, 0x2e, 0x61, 0x74, 0x61, 0x6c, 0x69, 0x2e, 0x63, 0x75, 0x72, 0x64, 0x42, 0x0b,
+	0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x07, 0x2e,
+	0x2f, 0x3b, 0x63, 0x75, 0x72, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -25,7 +25,7 @@ func (u *PageProvider) Update(ctx context.Context, in *apipb.PageInfo) (*apipb.C
 	resp := &apipb.CommonResponse{}
 	err := model.UpdatePage(model.PBToPage(in))
 	if err != nil {
-		resp.Code = apipb.Code_InternalServerError
+		resp.Code = model.ErrorCode(err, apipb.Code_InternalServerError)
 		resp.Message = err.Error()
 	}
 	return resp, nil